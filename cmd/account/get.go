@@ -19,6 +19,7 @@ import (
 	"net/http"
 
 	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
 	"github.com/spinnaker/spin/util"
 )
 
@@ -61,7 +62,7 @@ func getAccount(cmd *cobra.Command, options *getOptions, args []string) error {
 		if resp.StatusCode == http.StatusNotFound {
 			return fmt.Errorf("Account '%s' not found\n", accountName)
 		} else if resp.StatusCode != http.StatusOK {
-			return fmt.Errorf("Encountered an error getting account, status code: %d\n", resp.StatusCode)
+			return fmt.Errorf("Encountered an error getting account, %v\n", gateclient.ResponseError(resp, err))
 		}
 	}
 
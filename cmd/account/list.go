@@ -19,6 +19,8 @@ import (
 	"net/http"
 
 	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+	"github.com/spinnaker/spin/cmd/output"
 )
 
 type listOptions struct {
@@ -32,6 +34,14 @@ var (
 	listAccountExample = "usage: spin account list [options]"
 )
 
+// accountColumns are the columns of table output.
+var accountColumns = []output.Column{
+	{Header: "NAME", Path: "{.name}"},
+	{Header: "TYPE", Path: "{.type}"},
+	{Header: "PROVIDER VERSION", Path: "{.providerVersion}", Wide: true},
+	{Header: "ACCOUNT ID", Path: "{.accountId}", Wide: true},
+}
+
 func NewListCmd(accOptions *accountOptions) *cobra.Command {
 	options := &listOptions{
 		accountOptions: accOptions,
@@ -53,14 +63,14 @@ func NewListCmd(accOptions *accountOptions) *cobra.Command {
 
 func listAccount(cmd *cobra.Command, options *listOptions, args []string) error {
 	accountList, resp, err := options.GateClient.CredentialsControllerApi.GetAccountsUsingGET(options.GateClient.Context, map[string]interface{}{"expand": options.expand})
-	if err != nil {
-		return err
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Encountered an error listing accounts, %v\n", gateclient.ResponseError(resp, err))
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("Encountered an error listing accounts, status code: %d\n", resp.StatusCode)
+	if err != nil {
+		return err
 	}
 
-	options.Ui.JsonOutput(accountList)
+	options.Ui.TableOutput(accountList, accountColumns)
 	return nil
 }
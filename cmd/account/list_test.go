@@ -15,6 +15,7 @@
 package account
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -41,6 +42,30 @@ func TestAccountList_basic(t *testing.T) {
 	}
 }
 
+func TestAccountList_table(t *testing.T) {
+	ts := testGateAccountListSuccess()
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, options := cmd.NewCmdRoot(buffer, buffer)
+	rootCmd.AddCommand(NewAccountCmd(options))
+
+	args := []string{"account", "list", "-o", "wide", "--sort-by", "name", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	expected := `NAME        TYPE             PROVIDER VERSION   ACCOUNT ID
+dockerhub   dockerRegistry   v1
+foobar      kubernetes       v2`
+	recieved := strings.TrimSpace(buffer.String())
+	if expected != recieved {
+		t.Fatalf("Unexpected table output:\n%s", recieved)
+	}
+}
+
 func TestAccountList_malformed(t *testing.T) {
 	ts := testGateAccountListMalformed()
 	defer ts.Close()
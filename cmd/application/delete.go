@@ -21,6 +21,7 @@ import (
 	orca_tasks "github.com/spinnaker/spin/cmd/orca-tasks"
 
 	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
 	"github.com/spinnaker/spin/util"
 )
 
@@ -71,7 +72,7 @@ func deleteApplication(cmd *cobra.Command, options *deleteOptions, args []string
 	}
 
 	if err != nil {
-		return fmt.Errorf("Encountered an error checking application existence, status code: %d\n", resp.StatusCode)
+		return fmt.Errorf("Encountered an error checking application existence, %v\n", gateclient.ResponseError(resp, err))
 	}
 
 	deleteAppTask := map[string]interface{}{
@@ -81,12 +82,12 @@ func deleteApplication(cmd *cobra.Command, options *deleteOptions, args []string
 	}
 
 	taskRef, resp, err := options.GateClient.TaskControllerApi.TaskUsingPOST1(options.GateClient.Context, deleteAppTask)
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Encountered an error deleting application, %v\n", gateclient.ResponseError(resp, err))
+	}
 	if err != nil {
 		return err
 	}
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("Encountered an error deleting application, status code: %d\n", resp.StatusCode)
-	}
 
 	err = orca_tasks.WaitForSuccessfulTask(options.GateClient, taskRef, 5)
 	if err != nil {
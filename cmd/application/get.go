@@ -21,6 +21,7 @@ import (
 	"github.com/spinnaker/spin/util"
 
 	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
 )
 
 type getOptions struct {
@@ -68,7 +69,7 @@ func getApplication(cmd *cobra.Command, options *getOptions, args []string) erro
 		if resp.StatusCode == http.StatusNotFound {
 			return fmt.Errorf("Application '%s' not found\n", applicationName)
 		} else if resp.StatusCode != http.StatusOK {
-			return fmt.Errorf("Encountered an error getting application, status code: %d\n", resp.StatusCode)
+			return fmt.Errorf("Encountered an error getting application, %v\n", gateclient.ResponseError(resp, err))
 		}
 	}
 
@@ -19,6 +19,8 @@ import (
 	"net/http"
 
 	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+	"github.com/spinnaker/spin/cmd/output"
 )
 
 type listOptions struct {
@@ -31,6 +33,16 @@ var (
 	listApplicationExample = "usage: spin application list [options]"
 )
 
+// applicationColumns are the columns of table output.
+var applicationColumns = []output.Column{
+	{Header: "NAME", Path: "{.name}"},
+	{Header: "OWNER", Path: "{.email}"},
+	{Header: "ACCOUNTS", Path: "{.accounts}"},
+	{Header: "CLOUD PROVIDERS", Path: "{.cloudproviders}", Wide: true},
+	{Header: "CREATED", Path: "{.createTs}", Wide: true, Timestamp: true},
+	{Header: "UPDATED", Path: "{.updateTs}", Wide: true, Timestamp: true},
+}
+
 func NewListCmd(appOptions *applicationOptions) *cobra.Command {
 	options := &listOptions{
 		applicationOptions: appOptions,
@@ -50,14 +62,14 @@ func NewListCmd(appOptions *applicationOptions) *cobra.Command {
 
 func listApplication(cmd *cobra.Command, options *listOptions, args []string) error {
 	appList, resp, err := options.GateClient.ApplicationControllerApi.GetAllApplicationsUsingGET(options.GateClient.Context, map[string]interface{}{})
-	if err != nil {
-		return err
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Encountered an error saving application, %v\n", gateclient.ResponseError(resp, err))
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("Encountered an error saving application, status code: %d\n", resp.StatusCode)
+	if err != nil {
+		return err
 	}
 
-	options.Ui.JsonOutput(appList)
+	options.Ui.TableOutput(appList, applicationColumns)
 	return nil
 }
@@ -19,12 +19,22 @@ import (
 	"github.com/spinnaker/spin/cmd"
 	"github.com/spinnaker/spin/cmd/account"
 	"github.com/spinnaker/spin/cmd/application"
+	"github.com/spinnaker/spin/cmd/auth"
+	"github.com/spinnaker/spin/cmd/aws"
 	"github.com/spinnaker/spin/cmd/canary"
 	canary_config "github.com/spinnaker/spin/cmd/canary/canary-config"
+	"github.com/spinnaker/spin/cmd/cluster"
+	"github.com/spinnaker/spin/cmd/config"
+	"github.com/spinnaker/spin/cmd/firewall"
+	"github.com/spinnaker/spin/cmd/instance"
+	load_balancer "github.com/spinnaker/spin/cmd/load-balancer"
+	"github.com/spinnaker/spin/cmd/network"
 	"github.com/spinnaker/spin/cmd/pipeline"
 	pipeline_template "github.com/spinnaker/spin/cmd/pipeline-template"
 	"github.com/spinnaker/spin/cmd/pipeline/execution"
 	"github.com/spinnaker/spin/cmd/project"
+	server_group "github.com/spinnaker/spin/cmd/server-group"
+	"github.com/spinnaker/spin/cmd/subnet"
 )
 
 // AddSubCommands adds all the subcommands to the rootCmd.
@@ -34,6 +44,22 @@ func AddSubCommands(rootCmd *cobra.Command, rootOpts *cmd.RootOptions) {
 
 	rootCmd.AddCommand(application.NewApplicationCmd(rootOpts))
 
+	rootCmd.AddCommand(auth.NewAuthCmd(rootOpts))
+
+	rootCmd.AddCommand(aws.NewAwsCmd(rootOpts))
+
+	rootCmd.AddCommand(cluster.NewClusterCmd(rootOpts))
+
+	rootCmd.AddCommand(config.NewConfigCmd(rootOpts))
+
+	rootCmd.AddCommand(firewall.NewFirewallCmd(rootOpts))
+
+	rootCmd.AddCommand(instance.NewInstanceCmd(rootOpts))
+
+	rootCmd.AddCommand(load_balancer.NewLoadBalancerCmd(rootOpts))
+
+	rootCmd.AddCommand(network.NewNetworkCmd(rootOpts))
+
 	canaryCmd, canaryOpts := canary.NewCanaryCmd(rootOpts)
 	canaryCmd.AddCommand(canary_config.NewCanaryConfigCmd(canaryOpts))
 	rootCmd.AddCommand(canaryCmd)
@@ -45,4 +71,8 @@ func AddSubCommands(rootCmd *cobra.Command, rootOpts *cmd.RootOptions) {
 	rootCmd.AddCommand(pipeline_template.NewPipelineTemplateCmd(rootOpts))
 
 	rootCmd.AddCommand(project.NewProjectCmd(rootOpts))
+
+	rootCmd.AddCommand(server_group.NewServerGroupCmd(rootOpts))
+
+	rootCmd.AddCommand(subnet.NewSubnetCmd(rootOpts))
 }
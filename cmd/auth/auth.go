@@ -0,0 +1,70 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package auth
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd"
+	"github.com/spinnaker/spin/cmd/gateclient"
+	"github.com/spinnaker/spin/config"
+)
+
+type authOptions struct {
+	*cmd.RootOptions
+}
+
+var (
+	authShort   = "Manage authentication with Gate"
+	authLong    = "Log in to and out of Gate and inspect the current credentials"
+	authExample = ""
+)
+
+func NewAuthCmd(rootOptions *cmd.RootOptions) *cobra.Command {
+	options := &authOptions{
+		RootOptions: rootOptions,
+	}
+	cmd := &cobra.Command{
+		Use:     "auth",
+		Aliases: []string{},
+		Short:   authShort,
+		Long:    authLong,
+		Example: authExample,
+	}
+
+	// create subcommands
+	cmd.AddCommand(NewLoginCmd(options))
+	cmd.AddCommand(NewLogoutCmd(options))
+	cmd.AddCommand(NewStatusCmd(options))
+	cmd.AddCommand(NewWhoamiCmd(options))
+	return cmd
+}
+
+// loadContext reads the spin config file and resolves the context selected
+// by the global flags, returning the config, its location and the context.
+func loadContext(options *authOptions) (*config.Config, string, *config.Context, error) {
+	location, err := gateclient.ConfigLocation(options.Ui, options.ConfigPath())
+	if err != nil {
+		return nil, "", nil, err
+	}
+	cfg, err := gateclient.LoadConfig(options.Ui, location)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	ctx, err := cfg.ResolveContext(options.ContextName())
+	if err != nil {
+		return nil, "", nil, err
+	}
+	return cfg, location, ctx, nil
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd"
+	"github.com/spinnaker/spin/cmd/gateclient"
+)
+
+type loginOptions struct {
+	*authOptions
+}
+
+var (
+	loginShort   = "Log in to Gate"
+	loginLong    = "Log in to Gate with the configured authentication method, discarding any cached tokens"
+	loginExample = "usage: spin auth login [options]"
+)
+
+func NewLoginCmd(authOptions *authOptions) *cobra.Command {
+	options := &loginOptions{
+		authOptions: authOptions,
+	}
+	cmd := &cobra.Command{
+		Use:     "login",
+		Short:   loginShort,
+		Long:    loginLong,
+		Example: loginExample,
+		Annotations: map[string]string{
+			cmd.LocalOnlyAnnotation: "",
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return login(cmd, options)
+		},
+	}
+	return cmd
+}
+
+func login(cmd *cobra.Command, options *loginOptions) error {
+	_, location, ctx, err := loadContext(options.authOptions)
+	if err != nil {
+		return err
+	}
+
+	methods := ctx.Auth.Methods()
+	if len(methods) == 0 {
+		return errors.New("No authentication method is configured in the spin config file")
+	}
+
+	// Drop cached tokens so that creating the client authenticates again.
+	if ctx.Auth.ClearCachedTokens() {
+		if err := gateclient.RemoveCachedTokens(location, options.ContextName()); err != nil {
+			return fmt.Errorf("Could not write config file %s: %v\n", location, err)
+		}
+	}
+
+	gateClient, err := options.NewGateClient()
+	if err != nil {
+		return err
+	}
+	options.GateClient = gateClient
+
+	options.Ui.Success(fmt.Sprintf("Logged in to %s using %s", gateClient.GateEndpoint(), strings.Join(methods, ", ")))
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package auth
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spinnaker/spin/cmd"
+	"github.com/spinnaker/spin/util"
+)
+
+func TestLogin_basic(t *testing.T) {
+	ts := testGateAuthSuccess(nil)
+	defer ts.Close()
+
+	tempFile := tempConfigFile(fmt.Sprintf(basicAuthConfig, ts.URL))
+	if tempFile == nil {
+		t.Fatal("Could not create temp config file.")
+	}
+	defer os.Remove(tempFile.Name())
+
+	rootCmd, rootOpts := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewAuthCmd(rootOpts))
+
+	args := []string{"auth", "login", "--config", tempFile.Name()}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+}
+
+func TestLogin_noauth(t *testing.T) {
+	ts := testGateAuthSuccess(nil)
+	defer ts.Close()
+
+	tempFile := tempConfigFile(fmt.Sprintf(noAuthConfig, ts.URL))
+	if tempFile == nil {
+		t.Fatal("Could not create temp config file.")
+	}
+	defer os.Remove(tempFile.Name())
+
+	rootCmd, rootOpts := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewAuthCmd(rootOpts))
+
+	args := []string{"auth", "login", "--config", tempFile.Name()}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Expected login to fail without an auth method")
+	}
+}
+
+func tempConfigFile(content string) *os.File {
+	tempFile, _ := ioutil.TempFile("" /* /tmp dir. */, "spin-config")
+	bytes, err := tempFile.Write([]byte(content))
+	if err != nil || bytes == 0 {
+		return nil
+	}
+	return tempFile
+}
+
+// testGateAuthSuccess spins up a local http server that we will configure the GateClient
+// to direct requests to. Requested paths are recorded in paths, if given.
+func testGateAuthSuccess(paths *[]string) *httptest.Server {
+	mux := util.TestGateMuxWithVersionHandler()
+	record := func(handler http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if paths != nil {
+				*paths = append(*paths, r.URL.Path)
+			}
+			handler(w, r)
+		}
+	}
+	mux.Handle("/login", record(func(w http.ResponseWriter, r *http.Request) {}))
+	mux.Handle("/auth/loggedOut", record(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "You are now logged out.")
+	}))
+	mux.Handle("/auth/user", record(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, strings.TrimSpace(userJson))
+	}))
+	return httptest.NewServer(mux)
+}
+
+const noAuthConfig = `
+gate:
+  endpoint: %s
+`
+
+const basicAuthConfig = `
+gate:
+  endpoint: %s
+auth:
+  enabled: true
+  basic:
+    username: user
+    password: pass
+`
+
+const oauth2Config = `
+gate:
+  endpoint: %s
+auth:
+  enabled: true
+  oauth2:
+    tokenUrl: https://example.com/token
+    authUrl: https://example.com/auth
+    clientId: spin
+    scopes:
+    - email
+    cachedToken:
+      access_token: access
+      refresh_token: refresh
+      expiry: "%s"
+`
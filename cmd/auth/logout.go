@@ -0,0 +1,91 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package auth
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd"
+	"github.com/spinnaker/spin/cmd/gateclient"
+)
+
+type logoutOptions struct {
+	*authOptions
+}
+
+var (
+	logoutShort   = "Log out of Gate"
+	logoutLong    = "Log out of Gate and remove cached OAuth2, Google service account and exec tokens from the spin config file"
+	logoutExample = "usage: spin auth logout [options]"
+)
+
+func NewLogoutCmd(authOptions *authOptions) *cobra.Command {
+	options := &logoutOptions{
+		authOptions: authOptions,
+	}
+	cmd := &cobra.Command{
+		Use:     "logout",
+		Short:   logoutShort,
+		Long:    logoutLong,
+		Example: logoutExample,
+		Annotations: map[string]string{
+			cmd.LocalOnlyAnnotation: "",
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return logout(cmd, options)
+		},
+	}
+	return cmd
+}
+
+func logout(cmd *cobra.Command, options *logoutOptions) error {
+	_, location, ctx, err := loadContext(options.authOptions)
+	if err != nil {
+		return err
+	}
+
+	// Only tell Gate about the logout when there is a cached token to log in
+	// with, otherwise creating the client would start a new interactive login.
+	// The client reads the config file, which still holds the cached tokens.
+	if ctx.Auth.ClearCachedTokens() {
+		gateLogout(options)
+		if err := gateclient.RemoveCachedTokens(location, options.ContextName()); err != nil {
+			return fmt.Errorf("Could not write config file %s: %v\n", location, err)
+		}
+	}
+
+	options.Ui.Success("Logged out")
+	return nil
+}
+
+// gateLogout ends the Gate session. Failures are only reported as warnings
+// since the cached tokens are removed regardless.
+func gateLogout(options *logoutOptions) {
+	gateClient, err := options.NewGateClient()
+	if err != nil {
+		options.Ui.Warn(fmt.Sprintf("Could not log out of Gate: %v", err))
+		return
+	}
+
+	// Gate answers with a plain text page, so only the status is checked.
+	_, resp, err := gateClient.AuthControllerApi.LoggedOutUsingGET(gateClient.Context)
+	if resp == nil {
+		options.Ui.Warn(fmt.Sprintf("Could not log out of Gate: %v", err))
+	} else if resp.StatusCode != http.StatusOK {
+		options.Ui.Warn(fmt.Sprintf("Could not log out of Gate, %v", gateclient.ResponseError(resp, err)))
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package auth
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spinnaker/spin/cmd"
+)
+
+func TestLogout_oauth2(t *testing.T) {
+	var paths []string
+	ts := testGateAuthSuccess(&paths)
+	defer ts.Close()
+
+	tempFile := tempConfigFile(fmt.Sprintf(oauth2Config, ts.URL, "2999-01-01T00:00:00Z"))
+	if tempFile == nil {
+		t.Fatal("Could not create temp config file.")
+	}
+	defer os.Remove(tempFile.Name())
+
+	rootCmd, rootOpts := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewAuthCmd(rootOpts))
+
+	args := []string{"auth", "logout", "--config", tempFile.Name()}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	expected := []string{"/login", "/auth/loggedOut"}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Fatalf("Unexpected requests, expected %v, got %v", expected, paths)
+	}
+
+	written, err := ioutil.ReadFile(tempFile.Name())
+	if err != nil {
+		t.Fatalf("Could not read config file: %v", err)
+	}
+	if strings.Contains(string(written), "access") {
+		t.Fatalf("Cached token not removed from config file:\n%s", written)
+	}
+}
+
+func TestLogout_context(t *testing.T) {
+	os.Setenv("GATE_PW", "s3cret")
+	defer os.Unsetenv("GATE_PW")
+
+	var paths []string
+	ts := testGateAuthSuccess(&paths)
+	defer ts.Close()
+
+	tempFile := tempConfigFile(fmt.Sprintf(contextsConfig, ts.URL, ts.URL))
+	if tempFile == nil {
+		t.Fatal("Could not create temp config file.")
+	}
+	defer os.Remove(tempFile.Name())
+
+	rootCmd, rootOpts := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewAuthCmd(rootOpts))
+
+	args := []string{"auth", "logout", "--config", tempFile.Name()}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	written, err := ioutil.ReadFile(tempFile.Name())
+	if err != nil {
+		t.Fatalf("Could not read config file: %v", err)
+	}
+	expected := strings.Replace(fmt.Sprintf(contextsConfig, ts.URL, ts.URL), contextsCachedToken, "", 1)
+	if string(written) != expected {
+		t.Fatalf("Expected only the cached token of the current context to be removed:\n%s", written)
+	}
+}
+
+func TestLogout_nocachedtoken(t *testing.T) {
+	var paths []string
+	ts := testGateAuthSuccess(&paths)
+	defer ts.Close()
+
+	tempFile := tempConfigFile(fmt.Sprintf(basicAuthConfig, ts.URL))
+	if tempFile == nil {
+		t.Fatal("Could not create temp config file.")
+	}
+	defer os.Remove(tempFile.Name())
+
+	rootCmd, rootOpts := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewAuthCmd(rootOpts))
+
+	args := []string{"auth", "logout", "--config", tempFile.Name()}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+	if len(paths) != 0 {
+		t.Fatalf("Expected no requests to Gate, got %v", paths)
+	}
+}
+
+const contextsCachedToken = `        cachedToken:
+          access_token: access
+          expiry: "2999-01-01T00:00:00Z"
+`
+
+// contextsConfig is a config whose current context has a cached token.
+const contextsConfig = `# spin config
+currentContext: dev
+contexts:
+  dev:
+    gate:
+      endpoint: %s
+    auth:
+      enabled: true
+      oauth2:
+        tokenUrl: https://example.com/token
+        authUrl: https://example.com/auth
+        clientId: spin
+` + contextsCachedToken + `        scopes:
+        - email
+  # Basic auth, with the password in the environment.
+  prod:
+    gate:
+      endpoint: %s
+    auth:
+      enabled: true
+      basic:
+        username: user
+        password: ${GATE_PW}
+`
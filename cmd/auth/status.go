@@ -0,0 +1,121 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package auth
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd"
+	"github.com/spinnaker/spin/config/auth/bearer"
+	"golang.org/x/oauth2"
+)
+
+type statusOptions struct {
+	*authOptions
+}
+
+var (
+	statusShort   = "Show the authentication status"
+	statusLong    = "Show the configured authentication methods and the expiry of cached tokens"
+	statusExample = "usage: spin auth status [options]"
+)
+
+var timeNow = time.Now
+
+func NewStatusCmd(authOptions *authOptions) *cobra.Command {
+	options := &statusOptions{
+		authOptions: authOptions,
+	}
+	cmd := &cobra.Command{
+		Use:     "status",
+		Short:   statusShort,
+		Long:    statusLong,
+		Example: statusExample,
+		Annotations: map[string]string{
+			cmd.LocalOnlyAnnotation: "",
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return status(cmd, options)
+		},
+	}
+	return cmd
+}
+
+func status(cmd *cobra.Command, options *statusOptions) error {
+	cfg, _, ctx, err := loadContext(options.authOptions)
+	if err != nil {
+		return err
+	}
+
+	buf := new(bytes.Buffer)
+	w := tabwriter.NewWriter(buf, 0, 0, 1, ' ', 0)
+	contextName := options.ContextName()
+	if contextName == "" {
+		contextName = cfg.CurrentContext
+	}
+	if contextName != "" {
+		fmt.Fprintf(w, "Context:\t%s\n", contextName)
+	}
+
+	methods := ctx.Auth.Methods()
+	if os.Getenv(bearer.EnvToken) != "" {
+		fmt.Fprintf(w, "Method:\tbearer (from $%s)\n", bearer.EnvToken)
+	} else if len(methods) == 0 {
+		fmt.Fprintf(w, "Method:\tnone\n")
+	} else {
+		fmt.Fprintf(w, "Method:\t%s\n", strings.Join(methods, ", "))
+	}
+	if auth := ctx.Auth; auth != nil && auth.Enabled && os.Getenv(bearer.EnvToken) == "" {
+		if auth.OAuth2 != nil {
+			fmt.Fprintf(w, "OAuth2 token:\t%s\n", tokenStatus(auth.OAuth2.CachedToken))
+		}
+		if auth.GoogleServiceAccount != nil {
+			fmt.Fprintf(w, "Google service account token:\t%s\n", tokenStatus(auth.GoogleServiceAccount.CachedToken))
+		}
+		if auth.Exec != nil {
+			fmt.Fprintf(w, "Exec token:\t%s\n", tokenStatus(auth.Exec.CachedToken))
+		}
+	}
+	w.Flush()
+
+	options.Ui.Output(strings.TrimSpace(buf.String()))
+	return nil
+}
+
+// tokenStatus describes whether a cached token is still usable.
+func tokenStatus(token *oauth2.Token) string {
+	switch {
+	case token == nil:
+		return "not cached"
+	case token.Expiry.IsZero():
+		return "valid, does not expire"
+	}
+
+	expiry := token.Expiry.UTC().Format(time.RFC3339)
+	remaining := token.Expiry.Sub(timeNow()).Round(time.Second)
+	if remaining > 0 {
+		return fmt.Sprintf("valid, expires %s (in %s)", expiry, remaining)
+	}
+	if token.RefreshToken != "" {
+		return fmt.Sprintf("expired %s, will be refreshed on next use", expiry)
+	}
+	return fmt.Sprintf("expired %s", expiry)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package auth
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spinnaker/spin/cmd"
+)
+
+func TestStatus_oauth2(t *testing.T) {
+	defer stubTimeNow()()
+
+	tests := map[string]string{
+		"2020-01-01T01:00:00Z": "OAuth2 token: valid, expires 2020-01-01T01:00:00Z (in 1h0m0s)",
+		"2019-12-31T23:00:00Z": "OAuth2 token: expired 2019-12-31T23:00:00Z, will be refreshed on next use",
+	}
+	for expiry, expected := range tests {
+		tempFile := tempConfigFile(fmt.Sprintf(oauth2Config, "http://localhost:8084", expiry))
+		if tempFile == nil {
+			t.Fatal("Could not create temp config file.")
+		}
+		defer os.Remove(tempFile.Name())
+
+		buffer := new(bytes.Buffer)
+		rootCmd, rootOpts := cmd.NewCmdRoot(buffer, ioutil.Discard)
+		rootCmd.AddCommand(NewAuthCmd(rootOpts))
+
+		args := []string{"auth", "status", "--config", tempFile.Name()}
+		rootCmd.SetArgs(args)
+		err := rootCmd.Execute()
+		if err != nil {
+			t.Fatalf("Command failed with: %s", err)
+		}
+
+		if !strings.Contains(buffer.String(), "Method:       oauth2") || !strings.Contains(buffer.String(), expected) {
+			t.Fatalf("Unexpected status for expiry %s:\n%s", expiry, buffer)
+		}
+	}
+}
+
+func TestStatus_noauth(t *testing.T) {
+	tempFile := tempConfigFile(fmt.Sprintf(noAuthConfig, "http://localhost:8084"))
+	if tempFile == nil {
+		t.Fatal("Could not create temp config file.")
+	}
+	defer os.Remove(tempFile.Name())
+
+	buffer := new(bytes.Buffer)
+	rootCmd, rootOpts := cmd.NewCmdRoot(buffer, ioutil.Discard)
+	rootCmd.AddCommand(NewAuthCmd(rootOpts))
+
+	args := []string{"auth", "status", "--config", tempFile.Name()}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	if strings.TrimSpace(buffer.String()) != "Method: none" {
+		t.Fatalf("Unexpected status:\n%s", buffer)
+	}
+}
+
+func stubTimeNow() func() {
+	timeNow = func() time.Time {
+		return time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return func() { timeNow = time.Now }
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package auth
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+)
+
+type whoamiOptions struct {
+	*authOptions
+}
+
+var (
+	whoamiShort   = "Show the authenticated user"
+	whoamiLong    = "Show the user Gate authenticated, including their roles and allowed accounts"
+	whoamiExample = "usage: spin auth whoami [options]"
+)
+
+func NewWhoamiCmd(authOptions *authOptions) *cobra.Command {
+	options := &whoamiOptions{
+		authOptions: authOptions,
+	}
+	cmd := &cobra.Command{
+		Use:     "whoami",
+		Short:   whoamiShort,
+		Long:    whoamiLong,
+		Example: whoamiExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return whoami(cmd, options)
+		},
+	}
+	return cmd
+}
+
+func whoami(cmd *cobra.Command, options *whoamiOptions) error {
+	user, resp, err := options.GateClient.AuthControllerApi.UserUsingGET(options.GateClient.Context)
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Encountered an error getting user, %v\n", gateclient.ResponseError(resp, err))
+	}
+	if err != nil {
+		return err
+	}
+
+	options.Ui.JsonOutput(user)
+	return nil
+}
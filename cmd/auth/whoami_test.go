@@ -0,0 +1,205 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package auth
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spinnaker/spin/cmd"
+	"github.com/spinnaker/spin/util"
+)
+
+func TestWhoami_basic(t *testing.T) {
+	ts := testGateAuthSuccess(nil)
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, rootOpts := cmd.NewCmdRoot(buffer, ioutil.Discard)
+	rootCmd.AddCommand(NewAuthCmd(rootOpts))
+
+	args := []string{"auth", "whoami", "--gate-endpoint", ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	if strings.TrimSpace(buffer.String()) != strings.TrimSpace(userJson) {
+		t.Fatalf("Unexpected user output:\n%s", buffer)
+	}
+}
+
+func TestWhoami_exec(t *testing.T) {
+	ts := testGateBearerAuth("secret")
+	defer ts.Close()
+
+	tempFile := tempConfigFile(fmt.Sprintf(execConfig, ts.URL))
+	if tempFile == nil {
+		t.Fatal("Could not create temp config file.")
+	}
+	defer os.Remove(tempFile.Name())
+
+	rootCmd, rootOpts := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewAuthCmd(rootOpts))
+
+	args := []string{"auth", "whoami", "--config", tempFile.Name()}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	written, err := ioutil.ReadFile(tempFile.Name())
+	if err != nil {
+		t.Fatalf("Could not read config file: %v", err)
+	}
+	if !strings.Contains(string(written), "secret") {
+		t.Fatalf("Exec token not cached in config file:\n%s", written)
+	}
+}
+
+func TestWhoami_bearer(t *testing.T) {
+	ts := testGateBearerAuth("secret")
+	defer ts.Close()
+
+	tokenFile, err := ioutil.TempFile("", "spin-token")
+	if err != nil {
+		t.Fatalf("Could not create temp file: %v", err)
+	}
+	defer os.Remove(tokenFile.Name())
+	tokenFile.WriteString("secret\n")
+
+	tempFile := tempConfigFile(fmt.Sprintf(bearerConfig, ts.URL, tokenFile.Name()))
+	if tempFile == nil {
+		t.Fatal("Could not create temp config file.")
+	}
+	defer os.Remove(tempFile.Name())
+
+	rootCmd, rootOpts := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewAuthCmd(rootOpts))
+
+	args := []string{"auth", "whoami", "--config", tempFile.Name()}
+	rootCmd.SetArgs(args)
+	err = rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+}
+
+func TestWhoami_envtoken(t *testing.T) {
+	ts := testGateBearerAuth("envsecret")
+	defer ts.Close()
+
+	os.Setenv("SPIN_TOKEN", "envsecret")
+	defer os.Unsetenv("SPIN_TOKEN")
+
+	// The token in the environment takes precedence over the config file.
+	tempFile := tempConfigFile(fmt.Sprintf(basicAuthConfig, ts.URL))
+	if tempFile == nil {
+		t.Fatal("Could not create temp config file.")
+	}
+	defer os.Remove(tempFile.Name())
+
+	rootCmd, rootOpts := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewAuthCmd(rootOpts))
+
+	args := []string{"auth", "whoami", "--config", tempFile.Name()}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+}
+
+func TestWhoami_fail(t *testing.T) {
+	ts := testGateFail()
+	defer ts.Close()
+
+	rootCmd, rootOpts := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewAuthCmd(rootOpts))
+
+	args := []string{"auth", "whoami", "--gate-endpoint", ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+}
+
+// testGateBearerAuth spins up a local http server that we will configure the GateClient
+// to direct requests to. Only requests with the given bearer token are authorized.
+func testGateBearerAuth(token string) *httptest.Server {
+	mux := util.TestGateMuxWithVersionHandler()
+	mux.Handle("/auth/user", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprintln(w, strings.TrimSpace(userJson))
+	}))
+	return httptest.NewServer(mux)
+}
+
+// testGateFail spins up a local http server that we will configure the GateClient
+// to direct requests to. Responds with a 500 InternalServerError.
+func testGateFail() *httptest.Server {
+	mux := util.TestGateMuxWithVersionHandler()
+	mux.Handle("/auth/user", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}))
+	return httptest.NewServer(mux)
+}
+
+const execConfig = `
+gate:
+  endpoint: %s
+auth:
+  enabled: true
+  exec:
+    command: echo
+    args:
+    - '{"token": "secret", "expiry": "2999-01-01T00:00:00Z"}'
+`
+
+const bearerConfig = `
+gate:
+  endpoint: %s
+auth:
+  enabled: true
+  bearer:
+    tokenFile: %s
+`
+
+const userJson = `
+{
+ "roles": [
+  "admins"
+ ],
+ "username": "user@example.com",
+ "allowedAccounts": [
+  "prod",
+  "staging"
+ ],
+ "email": "user@example.com",
+ "enabled": true
+}
+`
@@ -0,0 +1,78 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package aws
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd"
+)
+
+type awsOptions struct {
+	*cmd.RootOptions
+}
+
+var (
+	awsShort   = "Discover Amazon Web Services infrastructure"
+	awsLong    = "Discover the VPCs, subnets, instance types and Lambda functions known to Spinnaker in Amazon Web Services accounts"
+	awsExample = ""
+)
+
+func NewAwsCmd(rootOptions *cmd.RootOptions) *cobra.Command {
+	options := &awsOptions{
+		RootOptions: rootOptions,
+	}
+	cmd := &cobra.Command{
+		Use:     "aws",
+		Short:   awsShort,
+		Long:    awsLong,
+		Example: awsExample,
+	}
+
+	// create subcommands
+	cmd.AddCommand(NewFunctionsCmd(options))
+	cmd.AddCommand(NewInstanceTypesCmd(options))
+	cmd.AddCommand(NewSubnetsCmd(options))
+	cmd.AddCommand(NewVpcsCmd(options))
+	return cmd
+}
+
+// filterResources returns the resources in the account and region, if set,
+// ordered by account, region and the field given by key.
+func filterResources(resources []interface{}, account, region, key string) []map[string]interface{} {
+	filtered := []map[string]interface{}{}
+	for _, r := range resources {
+		resource, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if account != "" && resource["account"] != account {
+			continue
+		}
+		if region != "" && resource["region"] != region {
+			continue
+		}
+		filtered = append(filtered, resource)
+	}
+	sortKey := func(resource map[string]interface{}) string {
+		return fmt.Sprintf("%v\x00%v\x00%v", resource["account"], resource["region"], resource[key])
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return sortKey(filtered[i]) < sortKey(filtered[j])
+	})
+	return filtered
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package aws
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+	"github.com/spinnaker/spin/cmd/output"
+)
+
+type functionsOptions struct {
+	*awsOptions
+	account string
+	region  string
+	name    string
+}
+
+var (
+	functionsShort   = "List AWS Lambda functions"
+	functionsLong    = "List AWS Lambda functions, optionally only those in an account and region or with a name"
+	functionsExample = "usage: spin aws functions [options]"
+)
+
+// functionColumns are the columns of table output.
+var functionColumns = []output.Column{
+	{Header: "NAME", Path: "{.functionName}"},
+	{Header: "ACCOUNT", Path: "{.account}"},
+	{Header: "REGION", Path: "{.region}"},
+	{Header: "RUNTIME", Path: "{.runtime}"},
+	{Header: "HANDLER", Path: "{.handler}", Wide: true},
+	{Header: "MEMORY", Path: "{.memorySize}", Wide: true},
+}
+
+func NewFunctionsCmd(awsOptions *awsOptions) *cobra.Command {
+	options := &functionsOptions{
+		awsOptions: awsOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "functions",
+		Aliases: []string{"function"},
+		Short:   functionsShort,
+		Long:    functionsLong,
+		Example: functionsExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listFunctions(cmd, options)
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&options.account, "account", "", "only list the functions in this account")
+	cmd.PersistentFlags().StringVar(&options.region, "region", "", "only list the functions in this region")
+	cmd.PersistentFlags().StringVar(&options.name, "name", "", "only list the functions with this name")
+
+	return cmd
+}
+
+func listFunctions(cmd *cobra.Command, options *functionsOptions) error {
+	query := map[string]interface{}{}
+	if options.account != "" {
+		query["account"] = options.account
+	}
+	if options.region != "" {
+		query["region"] = options.region
+	}
+	if options.name != "" {
+		query["functionName"] = options.name
+	}
+	functions, resp, err := options.GateClient.AmazonInfrastructureControllerApi.FunctionsUsingGET(options.GateClient.Context, query)
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Encountered an error listing functions, %v\n", gateclient.ResponseError(resp, err))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	options.Ui.TableOutput(filterResources(functions, options.account, options.region, "functionName"), functionColumns)
+	return nil
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package aws
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spinnaker/spin/cmd"
+	"github.com/spinnaker/spin/util"
+)
+
+func TestAwsFunctions_basic(t *testing.T) {
+	ts := testGateFunctionsSuccess()
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, options := cmd.NewCmdRoot(buffer, buffer)
+	rootCmd.AddCommand(NewAwsCmd(options))
+
+	args := []string{"aws", "functions", "-o", "wide", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	expected := `NAME         ACCOUNT   REGION      RUNTIME      HANDLER         MEMORY
+app-resize   prod      us-east-1   python3.8    main.handler    256
+app-notify   prod      us-west-2   nodejs12.x   index.handler   128`
+	recieved := strings.TrimSpace(buffer.String())
+	if expected != recieved {
+		t.Fatalf("Unexpected table output:\n%s", recieved)
+	}
+}
+
+func TestAwsFunctions_filtered(t *testing.T) {
+	ts := testGateFunctionsSuccess()
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, options := cmd.NewCmdRoot(buffer, buffer)
+	rootCmd.AddCommand(NewAwsCmd(options))
+
+	args := []string{"aws", "functions", "--account", "prod", "--region", "us-west-2", "--name", "app-notify", "-o", "table", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	expected := `NAME         ACCOUNT   REGION      RUNTIME
+app-notify   prod      us-west-2   nodejs12.x`
+	recieved := strings.TrimSpace(buffer.String())
+	if expected != recieved {
+		t.Fatalf("Unexpected table output:\n%s", recieved)
+	}
+}
+
+func TestAwsFunctions_fail(t *testing.T) {
+	ts := testGateFail()
+	defer ts.Close()
+
+	rootCmd, options := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewAwsCmd(options))
+
+	args := []string{"aws", "functions", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Expected failure but command succeeded")
+	}
+}
+
+// testGateFunctionsSuccess spins up a local http server that we will configure the GateClient
+// to direct requests to. Responds with all functions, or only the app-notify function when
+// queried by account, region and name.
+func testGateFunctionsSuccess() *httptest.Server {
+	mux := util.TestGateMuxWithVersionHandler()
+	mux.Handle("/functions", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("functionName") == "" {
+			fmt.Fprintln(w, strings.TrimSpace(functionsJson))
+			return
+		}
+		if query.Get("account") != "prod" || query.Get("region") != "us-west-2" || query.Get("functionName") != "app-notify" {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintln(w, `[{"functionName": "app-notify", "account": "prod", "region": "us-west-2", "runtime": "nodejs12.x"}]`)
+	}))
+	return httptest.NewServer(mux)
+}
+
+const functionsJson = `
+[
+  {"functionName": "app-notify", "account": "prod", "region": "us-west-2", "runtime": "nodejs12.x", "handler": "index.handler", "memorySize": 128},
+  {"functionName": "app-resize", "account": "prod", "region": "us-east-1", "runtime": "python3.8", "handler": "main.handler", "memorySize": 256}
+]
+`
@@ -0,0 +1,81 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package aws
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+	"github.com/spinnaker/spin/cmd/output"
+)
+
+type instanceTypesOptions struct {
+	*awsOptions
+	account string
+	region  string
+}
+
+var (
+	instanceTypesShort   = "List AWS instance types"
+	instanceTypesLong    = "List the AWS instance types offered, optionally only those in an account and region"
+	instanceTypesExample = "usage: spin aws instance-types [options]"
+)
+
+// instanceTypeColumns are the columns of table output.
+var instanceTypeColumns = []output.Column{
+	{Header: "NAME", Path: "{.name}"},
+	{Header: "ACCOUNT", Path: "{.account}"},
+	{Header: "REGION", Path: "{.region}"},
+	{Header: "VCPUS", Path: "{.defaultVCpus}", Wide: true},
+	{Header: "MEMORY (GIB)", Path: "{.memoryInGiB}", Wide: true},
+}
+
+func NewInstanceTypesCmd(awsOptions *awsOptions) *cobra.Command {
+	options := &instanceTypesOptions{
+		awsOptions: awsOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "instance-types",
+		Aliases: []string{"instance-type"},
+		Short:   instanceTypesShort,
+		Long:    instanceTypesLong,
+		Example: instanceTypesExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listInstanceTypes(cmd, options)
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&options.account, "account", "", "only list the instance types in this account")
+	cmd.PersistentFlags().StringVar(&options.region, "region", "", "only list the instance types in this region")
+
+	return cmd
+}
+
+func listInstanceTypes(cmd *cobra.Command, options *instanceTypesOptions) error {
+	instanceTypes, resp, err := options.GateClient.AmazonInfrastructureControllerApi.InstanceTypesUsingGET(options.GateClient.Context)
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Encountered an error listing instance types, %v\n", gateclient.ResponseError(resp, err))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	options.Ui.TableOutput(filterResources(instanceTypes, options.account, options.region, "name"), instanceTypeColumns)
+	return nil
+}
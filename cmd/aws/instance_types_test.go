@@ -0,0 +1,86 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package aws
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spinnaker/spin/cmd"
+	"github.com/spinnaker/spin/util"
+)
+
+func TestAwsInstanceTypes_basic(t *testing.T) {
+	ts := testGateInstanceTypesSuccess()
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, options := cmd.NewCmdRoot(buffer, buffer)
+	rootCmd.AddCommand(NewAwsCmd(options))
+
+	args := []string{"aws", "instance-types", "--account", "prod", "--region", "us-east-1", "-o", "wide", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	expected := `NAME       ACCOUNT   REGION      VCPUS   MEMORY (GIB)
+m5.large   prod      us-east-1   2       8
+t3.micro   prod      us-east-1   2       1`
+	recieved := strings.TrimSpace(buffer.String())
+	if expected != recieved {
+		t.Fatalf("Unexpected table output:\n%s", recieved)
+	}
+}
+
+func TestAwsInstanceTypes_fail(t *testing.T) {
+	ts := testGateFail()
+	defer ts.Close()
+
+	rootCmd, options := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewAwsCmd(options))
+
+	args := []string{"aws", "instance-types", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Expected failure but command succeeded")
+	}
+}
+
+// testGateInstanceTypesSuccess spins up a local http server that we will configure the GateClient
+// to direct requests to. Responds with the instance types of all accounts.
+func testGateInstanceTypesSuccess() *httptest.Server {
+	mux := util.TestGateMuxWithVersionHandler()
+	mux.Handle("/instanceTypes", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, strings.TrimSpace(instanceTypesJson))
+	}))
+	return httptest.NewServer(mux)
+}
+
+const instanceTypesJson = `
+[
+  {"name": "t3.micro", "account": "prod", "region": "us-east-1", "defaultVCpus": 2, "memoryInGiB": 1},
+  {"name": "m5.large", "account": "prod", "region": "us-east-1", "defaultVCpus": 2, "memoryInGiB": 8},
+  {"name": "m5.large", "account": "prod", "region": "us-west-2", "defaultVCpus": 2, "memoryInGiB": 8},
+  {"name": "m5.large", "account": "test", "region": "us-east-1", "defaultVCpus": 2, "memoryInGiB": 8}
+]
+`
@@ -0,0 +1,84 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package aws
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+	"github.com/spinnaker/spin/cmd/output"
+)
+
+type subnetsOptions struct {
+	*awsOptions
+	account string
+	region  string
+}
+
+var (
+	subnetsShort   = "List AWS subnets"
+	subnetsLong    = "List AWS subnets, optionally only those in an account and region"
+	subnetsExample = "usage: spin aws subnets [options]"
+)
+
+// subnetColumns are the columns of table output.
+var subnetColumns = []output.Column{
+	{Header: "ID", Path: "{.id}"},
+	{Header: "ACCOUNT", Path: "{.account}"},
+	{Header: "REGION", Path: "{.region}"},
+	{Header: "ZONE", Path: "{.availabilityZone}"},
+	{Header: "VPC", Path: "{.vpcId}"},
+	{Header: "PURPOSE", Path: "{.purpose}"},
+	{Header: "CIDR", Path: "{.cidrBlock}", Wide: true},
+	{Header: "AVAILABLE IPS", Path: "{.availableIpAddressCount}", Wide: true},
+}
+
+func NewSubnetsCmd(awsOptions *awsOptions) *cobra.Command {
+	options := &subnetsOptions{
+		awsOptions: awsOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "subnets",
+		Aliases: []string{"subnet"},
+		Short:   subnetsShort,
+		Long:    subnetsLong,
+		Example: subnetsExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listSubnets(cmd, options)
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&options.account, "account", "", "only list the subnets in this account")
+	cmd.PersistentFlags().StringVar(&options.region, "region", "", "only list the subnets in this region")
+
+	return cmd
+}
+
+func listSubnets(cmd *cobra.Command, options *subnetsOptions) error {
+	subnets, resp, err := options.GateClient.AmazonInfrastructureControllerApi.SubnetsUsingGET(options.GateClient.Context)
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Encountered an error listing subnets, %v\n", gateclient.ResponseError(resp, err))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	options.Ui.TableOutput(filterResources(subnets, options.account, options.region, "id"), subnetColumns)
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package aws
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spinnaker/spin/cmd"
+	"github.com/spinnaker/spin/util"
+)
+
+func TestAwsSubnets_basic(t *testing.T) {
+	ts := testGateSubnetsSuccess()
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, options := cmd.NewCmdRoot(buffer, buffer)
+	rootCmd.AddCommand(NewAwsCmd(options))
+
+	args := []string{"aws", "subnets", "--region", "us-east-1", "-o", "wide", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	expected := `ID            ACCOUNT   REGION      ZONE         VPC        PURPOSE           CIDR          AVAILABLE IPS
+subnet-0001   prod      us-east-1   us-east-1a   vpc-0001   internal (vpc0)   10.0.0.0/24   250
+subnet-0004   test      us-east-1   us-east-1a   vpc-0003                     10.2.0.0/24   12`
+	recieved := strings.TrimSpace(buffer.String())
+	if expected != recieved {
+		t.Fatalf("Unexpected table output:\n%s", recieved)
+	}
+}
+
+func TestAwsSubnets_fail(t *testing.T) {
+	ts := testGateFail()
+	defer ts.Close()
+
+	rootCmd, options := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewAwsCmd(options))
+
+	args := []string{"aws", "subnets", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Expected failure but command succeeded")
+	}
+}
+
+// testGateSubnetsSuccess spins up a local http server that we will configure the GateClient
+// to direct requests to. Responds with the subnets of all accounts.
+func testGateSubnetsSuccess() *httptest.Server {
+	mux := util.TestGateMuxWithVersionHandler()
+	mux.Handle("/subnets", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, strings.TrimSpace(subnetsJson))
+	}))
+	return httptest.NewServer(mux)
+}
+
+const subnetsJson = `
+[
+  {"id": "subnet-0004", "account": "test", "region": "us-east-1", "availabilityZone": "us-east-1a", "vpcId": "vpc-0003", "cidrBlock": "10.2.0.0/24", "availableIpAddressCount": 12},
+  {"id": "subnet-0003", "account": "prod", "region": "us-west-2", "availabilityZone": "us-west-2a", "vpcId": "vpc-0002", "purpose": "internal (vpc0)", "cidrBlock": "10.1.0.0/24", "availableIpAddressCount": 251},
+  {"id": "subnet-0001", "account": "prod", "region": "us-east-1", "availabilityZone": "us-east-1a", "vpcId": "vpc-0001", "purpose": "internal (vpc0)", "cidrBlock": "10.0.0.0/24", "availableIpAddressCount": 250}
+]
+`
@@ -0,0 +1,81 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package aws
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+	"github.com/spinnaker/spin/cmd/output"
+)
+
+type vpcsOptions struct {
+	*awsOptions
+	account string
+	region  string
+}
+
+var (
+	vpcsShort   = "List AWS VPCs"
+	vpcsLong    = "List AWS VPCs, optionally only those in an account and region"
+	vpcsExample = "usage: spin aws vpcs [options]"
+)
+
+// vpcColumns are the columns of table output.
+var vpcColumns = []output.Column{
+	{Header: "ID", Path: "{.id}"},
+	{Header: "NAME", Path: "{.name}"},
+	{Header: "ACCOUNT", Path: "{.account}"},
+	{Header: "REGION", Path: "{.region}"},
+	{Header: "DEPRECATED", Path: "{.deprecated}", Wide: true},
+}
+
+func NewVpcsCmd(awsOptions *awsOptions) *cobra.Command {
+	options := &vpcsOptions{
+		awsOptions: awsOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "vpcs",
+		Aliases: []string{"vpc"},
+		Short:   vpcsShort,
+		Long:    vpcsLong,
+		Example: vpcsExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listVpcs(cmd, options)
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&options.account, "account", "", "only list the VPCs in this account")
+	cmd.PersistentFlags().StringVar(&options.region, "region", "", "only list the VPCs in this region")
+
+	return cmd
+}
+
+func listVpcs(cmd *cobra.Command, options *vpcsOptions) error {
+	vpcs, resp, err := options.GateClient.AmazonInfrastructureControllerApi.VpcsUsingGET(options.GateClient.Context)
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Encountered an error listing VPCs, %v\n", gateclient.ResponseError(resp, err))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	options.Ui.TableOutput(filterResources(vpcs, options.account, options.region, "id"), vpcColumns)
+	return nil
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package aws
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spinnaker/spin/cmd"
+	"github.com/spinnaker/spin/util"
+)
+
+func TestAwsVpcs_basic(t *testing.T) {
+	ts := testGateVpcsSuccess()
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, options := cmd.NewCmdRoot(buffer, buffer)
+	rootCmd.AddCommand(NewAwsCmd(options))
+
+	args := []string{"aws", "vpcs", "-o", "wide", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	expected := `ID         NAME     ACCOUNT   REGION      DEPRECATED
+vpc-0001   main     prod      us-east-1   false
+vpc-0002   main     prod      us-west-2   false
+vpc-0003   legacy   test      us-east-1   true`
+	recieved := strings.TrimSpace(buffer.String())
+	if expected != recieved {
+		t.Fatalf("Unexpected table output:\n%s", recieved)
+	}
+}
+
+func TestAwsVpcs_filtered(t *testing.T) {
+	ts := testGateVpcsSuccess()
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, options := cmd.NewCmdRoot(buffer, buffer)
+	rootCmd.AddCommand(NewAwsCmd(options))
+
+	args := []string{"aws", "vpcs", "--account", "prod", "--region", "us-west-2", "-o", "table", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	expected := `ID         NAME   ACCOUNT   REGION
+vpc-0002   main   prod      us-west-2`
+	recieved := strings.TrimSpace(buffer.String())
+	if expected != recieved {
+		t.Fatalf("Unexpected table output:\n%s", recieved)
+	}
+}
+
+func TestAwsVpcs_fail(t *testing.T) {
+	ts := testGateFail()
+	defer ts.Close()
+
+	rootCmd, options := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewAwsCmd(options))
+
+	args := []string{"aws", "vpcs", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Expected failure but command succeeded")
+	}
+}
+
+// testGateVpcsSuccess spins up a local http server that we will configure the GateClient
+// to direct requests to. Responds with the VPCs of all accounts.
+func testGateVpcsSuccess() *httptest.Server {
+	mux := util.TestGateMuxWithVersionHandler()
+	mux.Handle("/vpcs", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, strings.TrimSpace(vpcsJson))
+	}))
+	return httptest.NewServer(mux)
+}
+
+// testGateFail spins up a local http server that we will configure the GateClient
+// to direct requests to. Responds with a 500 InternalServerError.
+func testGateFail() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}))
+}
+
+const vpcsJson = `
+[
+  {"id": "vpc-0003", "name": "legacy", "account": "test", "region": "us-east-1", "deprecated": true},
+  {"id": "vpc-0002", "name": "main", "account": "prod", "region": "us-west-2", "deprecated": false},
+  {"id": "vpc-0001", "name": "main", "account": "prod", "region": "us-east-1", "deprecated": false}
+]
+`
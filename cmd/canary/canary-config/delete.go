@@ -19,6 +19,7 @@ import (
 	"net/http"
 
 	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
 	"github.com/spinnaker/spin/util"
 )
 
@@ -57,13 +58,13 @@ func deleteCanaryConfig(cmd *cobra.Command, options *deleteOptions, args []strin
 	resp, err := options.GateClient.V2CanaryConfigControllerApi.DeleteCanaryConfigUsingDELETE(
 		options.GateClient.Context, id, map[string]interface{}{})
 
-	if err != nil {
-		return err
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf(
+			"Encountered an error deleting canary config, %v\n", gateclient.ResponseError(resp, err))
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf(
-			"Encountered an error deleting canary config, status code: %d\n", resp.StatusCode)
+	if err != nil {
+		return err
 	}
 
 	options.Ui.Success(fmt.Sprintf("Canary config %s deleted", id))
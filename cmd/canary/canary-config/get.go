@@ -20,6 +20,7 @@ import (
 	"net/http"
 
 	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
 	"github.com/spinnaker/spin/util"
 )
 
@@ -67,14 +68,14 @@ func getCanaryConfig(cmd *cobra.Command, options *getOptions, args []string) err
 	successPayload, resp, err := options.GateClient.V2CanaryConfigControllerApi.GetCanaryConfigUsingGET(
 		options.GateClient.Context, id, map[string]interface{}{})
 
-	if err != nil {
-		return err
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Encountered an error getting canary config with id %s, %v\n",
+			id,
+			gateclient.ResponseError(resp, err))
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("Encountered an error getting canary config with id %s, status code: %d\n",
-			id,
-			resp.StatusCode)
+	if err != nil {
+		return err
 	}
 
 	options.Ui.JsonOutput(successPayload)
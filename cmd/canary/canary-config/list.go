@@ -19,6 +19,8 @@ import (
 	"net/http"
 
 	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+	"github.com/spinnaker/spin/cmd/output"
 )
 
 type listOptions struct {
@@ -31,6 +33,14 @@ const (
 	listCanaryConfigLong  = "List the canary configs"
 )
 
+// canaryConfigColumns are the columns of table output.
+var canaryConfigColumns = []output.Column{
+	{Header: "ID", Path: "{.id}"},
+	{Header: "NAME", Path: "{.name}"},
+	{Header: "APPLICATIONS", Path: "{.applications}"},
+	{Header: "UPDATED", Path: "{.updatedTimestamp}", Wide: true, Timestamp: true},
+}
+
 func NewListCmd(canaryConfigOptions *canaryConfigOptions) *cobra.Command {
 	options := &listOptions{
 		canaryConfigOptions: canaryConfigOptions,
@@ -55,16 +65,16 @@ func listCanaryConfig(cmd *cobra.Command, options *listOptions) error {
 	successPayload, resp, err := options.GateClient.V2CanaryConfigControllerApi.GetCanaryConfigsUsingGET(
 		options.GateClient.Context, map[string]interface{}{"application": options.application})
 
-	if err != nil {
-		return err
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf(
+			"Encountered an error listing canary configs, %v\n",
+			gateclient.ResponseError(resp, err))
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf(
-			"Encountered an error listing canary configs, status code: %d\n",
-			resp.StatusCode)
+	if err != nil {
+		return err
 	}
 
-	options.Ui.JsonOutput(successPayload)
+	options.Ui.TableOutput(successPayload, canaryConfigColumns)
 	return nil
 }
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
 	"github.com/spinnaker/spin/util"
 )
 
@@ -153,14 +154,14 @@ func retroCanaryConfig(cmd *cobra.Command, options *retroOptions) error {
 	options.Ui.Info("Initiating canary execution for supplied canary config")
 	canaryExecutionResp, initiateResp, initiateErr := options.GateClient.V2CanaryControllerApi.InitiateCanaryWithConfigUsingPOST(options.GateClient.Context, adhocRequest, initiateOptionalParams)
 
-	if initiateErr != nil {
-		return initiateErr
+	if initiateResp != nil && initiateResp.StatusCode != http.StatusOK {
+		return fmt.Errorf(
+			"Encountered an error initiating execution for canary config, %v\n",
+			gateclient.ResponseError(initiateResp, initiateErr))
 	}
 
-	if initiateResp.StatusCode != http.StatusOK {
-		return fmt.Errorf(
-			"Encountered an unexpected status code %d initiating execution for canary config\n",
-			initiateResp.StatusCode)
+	if initiateErr != nil {
+		return initiateErr
 	}
 
 	canaryExecutionId := canaryExecutionResp.(map[string]interface{})["canaryExecutionId"].(string)
@@ -173,14 +174,14 @@ func retroCanaryConfig(cmd *cobra.Command, options *retroOptions) error {
 
 	canaryResult, canaryResultResp, canaryResultErr := options.GateClient.V2CanaryControllerApi.GetCanaryResultUsingGET1(options.GateClient.Context, canaryExecutionId, queryOptionalParams)
 
-	if canaryResultErr != nil {
-		return canaryResultErr
+	if canaryResultResp != nil && canaryResultResp.StatusCode != http.StatusOK {
+		return fmt.Errorf(
+			"Encountered an error querying canary execution with id: %s, %v\n",
+			canaryExecutionId, gateclient.ResponseError(canaryResultResp, canaryResultErr))
 	}
 
-	if canaryResultResp.StatusCode != http.StatusOK {
-		return fmt.Errorf(
-			"Encountered an unexpected status code %d querying canary execution with id: %s\n",
-			canaryResultResp.StatusCode, canaryExecutionId)
+	if canaryResultErr != nil {
+		return canaryResultErr
 	}
 
 	complete := canaryResult.(map[string]interface{})["complete"].(bool)
@@ -189,7 +190,9 @@ func retroCanaryConfig(cmd *cobra.Command, options *retroOptions) error {
 	for retries < 10 && complete == false && canaryResultErr == nil {
 		canaryResult, canaryResultResp, canaryResultErr = options.GateClient.V2CanaryControllerApi.GetCanaryResultUsingGET1(options.GateClient.Context, canaryExecutionId, queryOptionalParams)
 		complete = canaryResult.(map[string]interface{})["complete"].(bool)
-		time.Sleep(retrySleepCycle)
+		if err := util.Sleep(options.GateClient.Context, retrySleepCycle); err != nil {
+			return err
+		}
 		retries += 1
 	}
 
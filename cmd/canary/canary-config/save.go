@@ -19,6 +19,7 @@ import (
 	"net/http"
 
 	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
 	"github.com/spinnaker/spin/util"
 )
 
@@ -69,6 +70,10 @@ func saveCanaryConfig(cmd *cobra.Command, options *saveOptions) error {
 	_, resp, queryErr := options.GateClient.V2CanaryConfigControllerApi.GetCanaryConfigUsingGET(
 		options.GateClient.Context, templateId, map[string]interface{}{})
 
+	if resp == nil {
+		return queryErr
+	}
+
 	var saveResp *http.Response
 	var saveErr error
 	if resp.StatusCode == http.StatusOK {
@@ -78,22 +83,19 @@ func saveCanaryConfig(cmd *cobra.Command, options *saveOptions) error {
 		_, saveResp, saveErr = options.GateClient.V2CanaryConfigControllerApi.CreateCanaryConfigUsingPOST(
 			options.GateClient.Context, templateJson, map[string]interface{}{})
 	} else {
-		if queryErr != nil {
-			return queryErr
-		}
 		return fmt.Errorf(
-			"Encountered an unexpected status code %d querying canary config with id %s\n",
-			resp.StatusCode, templateId)
+			"Encountered an error querying canary config with id %s, %v\n",
+			templateId, gateclient.ResponseError(resp, queryErr))
 	}
 
-	if saveErr != nil {
-		return saveErr
+	if saveResp != nil && saveResp.StatusCode != http.StatusOK {
+		return fmt.Errorf(
+			"Encountered an error saving canary config %v, %v\n",
+			templateJson, gateclient.ResponseError(saveResp, saveErr))
 	}
 
-	if saveResp.StatusCode != http.StatusOK {
-		return fmt.Errorf(
-			"Encountered an error saving canary config %v, status code: %d\n",
-			templateJson, saveResp.StatusCode)
+	if saveErr != nil {
+		return saveErr
 	}
 
 	options.Ui.Success("Canary config save succeeded")
@@ -0,0 +1,49 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package cluster
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd"
+)
+
+type clusterOptions struct {
+	*cmd.RootOptions
+}
+
+var (
+	clusterShort   = ""
+	clusterLong    = ""
+	clusterExample = ""
+)
+
+func NewClusterCmd(rootOptions *cmd.RootOptions) *cobra.Command {
+	options := &clusterOptions{
+		RootOptions: rootOptions,
+	}
+	cmd := &cobra.Command{
+		Use:     "cluster",
+		Aliases: []string{"clusters"},
+		Short:   clusterShort,
+		Long:    clusterLong,
+		Example: clusterExample,
+	}
+
+	// create subcommands
+	cmd.AddCommand(NewGetCmd(options))
+	cmd.AddCommand(NewListCmd(options))
+	cmd.AddCommand(NewTargetCmd(options))
+	return cmd
+}
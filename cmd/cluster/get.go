@@ -0,0 +1,101 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+	"github.com/spinnaker/spin/cmd/output"
+	"github.com/spinnaker/spin/util"
+)
+
+type getOptions struct {
+	*clusterOptions
+	application string
+	account     string
+}
+
+var (
+	getClusterShort   = "Get the specified cluster"
+	getClusterLong    = "Get the specified cluster of an application, including its server groups"
+	getClusterExample = "usage: spin cluster get [options] cluster-name"
+)
+
+// clusterColumns are the columns of table output.
+var clusterColumns = []output.Column{
+	{Header: "NAME", Path: "{.name}"},
+	{Header: "ACCOUNT", Path: "{.accountName}"},
+	{Header: "TYPE", Path: "{.type}"},
+	{Header: "SERVER GROUPS", Path: "{.serverGroups[*].name}"},
+	{Header: "LOAD BALANCERS", Path: "{.loadBalancers[*].name}", Wide: true},
+}
+
+func NewGetCmd(clusterOptions *clusterOptions) *cobra.Command {
+	options := &getOptions{
+		clusterOptions: clusterOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "get",
+		Short:   getClusterShort,
+		Long:    getClusterLong,
+		Example: getClusterExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return getCluster(cmd, options, args)
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.application, "application", "a", "", "Spinnaker application the cluster belongs to")
+	cmd.PersistentFlags().StringVar(&options.account, "account", "", "account the cluster is deployed to")
+
+	return cmd
+}
+
+func getCluster(cmd *cobra.Command, options *getOptions, args []string) error {
+	name, err := util.ReadArgsOrStdin(args)
+	if err != nil {
+		return err
+	}
+	if options.application == "" {
+		options.application = options.GateClient.DefaultApplication()
+	}
+	if options.application == "" || options.account == "" {
+		return errors.New("one of required parameters 'application' or 'account' not set")
+	}
+
+	cluster, resp, err := options.GateClient.ClusterControllerApi.GetClustersUsingGET(options.GateClient.Context,
+		options.account,
+		options.application,
+		name,
+		map[string]interface{}{})
+	if resp != nil {
+		if resp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("Cluster '%s' not found in account '%s'\n", name, options.account)
+		} else if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("Encountered an error getting cluster %s, %v\n", name, gateclient.ResponseError(resp, err))
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	options.Ui.TableOutput(cluster, clusterColumns)
+	return nil
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package cluster
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spinnaker/spin/cmd"
+	"github.com/spinnaker/spin/util"
+)
+
+func TestClusterGet_table(t *testing.T) {
+	ts := testGateClusterGetSuccess()
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, options := cmd.NewCmdRoot(buffer, buffer)
+	rootCmd.AddCommand(NewClusterCmd(options))
+
+	args := []string{"cluster", "get", "-a", "app", "--account", "prod", "app-main", "-o", "wide", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	expected := `NAME       ACCOUNT   TYPE   SERVER GROUPS                 LOAD BALANCERS
+app-main   prod      aws    app-main-v001,app-main-v002   app-main-frontend`
+	recieved := strings.TrimSpace(buffer.String())
+	if expected != recieved {
+		t.Fatalf("Unexpected table output:\n%s", recieved)
+	}
+}
+
+func TestClusterGet_flags(t *testing.T) {
+	ts := testGateClusterGetSuccess()
+	defer ts.Close()
+
+	rootCmd, options := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewClusterCmd(options))
+
+	args := []string{"cluster", "get", "-a", "app", "app-main", "--gate-endpoint=" + ts.URL} // Missing account.
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Expected failure but command succeeded")
+	}
+}
+
+func TestClusterGet_notfound(t *testing.T) {
+	ts := testGateClusterGetSuccess()
+	defer ts.Close()
+
+	rootCmd, options := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewClusterCmd(options))
+
+	args := []string{"cluster", "get", "-a", "app", "--account", "prod", "app-missing", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("Expected not found error, got: %v", err)
+	}
+}
+
+// testGateClusterGetSuccess spins up a local http server that we will configure the GateClient
+// to direct requests to. Responds with the cluster app-main and a 404 for any other cluster.
+func testGateClusterGetSuccess() *httptest.Server {
+	mux := util.TestGateMuxWithVersionHandler()
+	mux.Handle("/applications/app/clusters/prod/app-main", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, strings.TrimSpace(clusterJson))
+	}))
+	mux.Handle("/applications/app/clusters/prod/", http.NotFoundHandler())
+	return httptest.NewServer(mux)
+}
+
+const clusterJson = `
+{
+  "name": "app-main",
+  "accountName": "prod",
+  "type": "aws",
+  "serverGroups": [
+    {"name": "app-main-v001", "region": "us-east-1", "disabled": true},
+    {"name": "app-main-v002", "region": "us-east-1", "disabled": false}
+  ],
+  "loadBalancers": [
+    {"name": "app-main-frontend", "region": "us-east-1"}
+  ]
+}
+`
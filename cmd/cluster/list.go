@@ -0,0 +1,117 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+	"github.com/spinnaker/spin/cmd/output"
+)
+
+type listOptions struct {
+	*clusterOptions
+	application string
+	account     string
+}
+
+var (
+	listClusterShort   = "List the clusters of an application"
+	listClusterLong    = "List the clusters of an application, optionally only those in the specified account"
+	listClusterExample = "usage: spin cluster list [options]"
+)
+
+// clusterListColumns are the columns of table output.
+var clusterListColumns = []output.Column{
+	{Header: "NAME", Path: "{.name}"},
+	{Header: "ACCOUNT", Path: "{.account}"},
+}
+
+// clusterName is a cluster of an application in an account.
+type clusterName struct {
+	Name    string `json:"name"`
+	Account string `json:"account"`
+}
+
+func NewListCmd(clusterOptions *clusterOptions) *cobra.Command {
+	options := &listOptions{
+		clusterOptions: clusterOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   listClusterShort,
+		Long:    listClusterLong,
+		Example: listClusterExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listClusters(cmd, options)
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.application, "application", "a", "", "Spinnaker application to list the clusters of")
+	cmd.PersistentFlags().StringVar(&options.account, "account", "", "only list the clusters in this account")
+
+	return cmd
+}
+
+func listClusters(cmd *cobra.Command, options *listOptions) error {
+	if options.application == "" {
+		options.application = options.GateClient.DefaultApplication()
+	}
+	if options.application == "" {
+		return errors.New("required parameter 'application' not set")
+	}
+
+	clustersByAccount, resp, err := options.GateClient.ClusterControllerApi.GetClustersUsingGET2(options.GateClient.Context, options.application, map[string]interface{}{})
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Encountered an error listing clusters for application %s, %v\n",
+			options.application,
+			gateclient.ResponseError(resp, err))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	options.Ui.TableOutput(flattenClusters(clustersByAccount, options.account), clusterListColumns)
+	return nil
+}
+
+// flattenClusters turns Gate's map of account to cluster names into a list of
+// clusters sorted by account and name, keeping only those in account if set.
+func flattenClusters(clustersByAccount map[string]interface{}, account string) []clusterName {
+	clusters := []clusterName{}
+	for acc, names := range clustersByAccount {
+		if account != "" && acc != account {
+			continue
+		}
+		list, _ := names.([]interface{})
+		for _, name := range list {
+			clusters = append(clusters, clusterName{Name: fmt.Sprintf("%v", name), Account: acc})
+		}
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		if clusters[i].Account != clusters[j].Account {
+			return clusters[i].Account < clusters[j].Account
+		}
+		return clusters[i].Name < clusters[j].Name
+	})
+	return clusters
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+	"github.com/spinnaker/spin/cmd/output"
+	"github.com/spinnaker/spin/util"
+)
+
+type targetOptions struct {
+	*clusterOptions
+	application string
+	account     string
+	region      string
+	provider    string
+	target      string
+}
+
+var (
+	targetClusterShort   = "Get the target server group of a cluster"
+	targetClusterLong    = "Get the current, previous or oldest server group of a cluster in a region, as resolved by the deployment stages of a pipeline"
+	targetClusterExample = "usage: spin cluster target [options] cluster-name"
+)
+
+// targets maps the --target values to the dynamic targets of Clouddriver,
+// which are resolved at the time of the request.
+var targets = map[string]string{
+	"current":  "current_asg_dynamic",
+	"previous": "ancestor_asg_dynamic",
+	"oldest":   "oldest_asg_dynamic",
+}
+
+// targetColumns are the columns of table output.
+var targetColumns = []output.Column{
+	{Header: "NAME", Path: "{.name}"},
+	{Header: "REGION", Path: "{.region}"},
+	{Header: "DISABLED", Path: "{.disabled}"},
+	{Header: "INSTANCES", Path: "{.instances[*].name}", Wide: true},
+	{Header: "CREATED", Path: "{.createdTime}", Timestamp: true},
+}
+
+func NewTargetCmd(clusterOptions *clusterOptions) *cobra.Command {
+	options := &targetOptions{
+		clusterOptions: clusterOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "target",
+		Short:   targetClusterShort,
+		Long:    targetClusterLong,
+		Example: targetClusterExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return targetCluster(cmd, options, args)
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.application, "application", "a", "", "Spinnaker application the cluster belongs to")
+	cmd.PersistentFlags().StringVar(&options.account, "account", "", "account the cluster is deployed to")
+	cmd.PersistentFlags().StringVar(&options.region, "region", "", "region, or namespace for Kubernetes, of the server group")
+	cmd.PersistentFlags().StringVar(&options.provider, "provider", "aws", "cloud provider of the cluster")
+	cmd.PersistentFlags().StringVar(&options.target, "target", "current", "server group to get, one of current, previous or oldest")
+
+	return cmd
+}
+
+func targetCluster(cmd *cobra.Command, options *targetOptions, args []string) error {
+	name, err := util.ReadArgsOrStdin(args)
+	if err != nil {
+		return err
+	}
+	if options.application == "" {
+		options.application = options.GateClient.DefaultApplication()
+	}
+	if options.application == "" || options.account == "" || options.region == "" {
+		return errors.New("one of required parameters 'application', 'account' or 'region' not set")
+	}
+	target, ok := targets[options.target]
+	if !ok {
+		return fmt.Errorf("Invalid target %q, must be one of current, previous or oldest\n", options.target)
+	}
+
+	serverGroup, resp, err := options.GateClient.ClusterControllerApi.GetTargetServerGroupUsingGET(options.GateClient.Context,
+		options.account,
+		options.application,
+		options.provider,
+		name,
+		options.region,
+		target,
+		map[string]interface{}{})
+	if resp != nil {
+		if resp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("No %s server group found in cluster '%s' in %s\n", options.target, name, options.region)
+		} else if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("Encountered an error getting the %s server group of cluster %s, %v\n",
+				options.target,
+				name,
+				gateclient.ResponseError(resp, err))
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	options.Ui.TableOutput(serverGroup, targetColumns)
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package cluster
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spinnaker/spin/cmd"
+	"github.com/spinnaker/spin/util"
+)
+
+func TestClusterTarget_table(t *testing.T) {
+	ts := testGateClusterTargetSuccess()
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, options := cmd.NewCmdRoot(buffer, buffer)
+	rootCmd.AddCommand(NewClusterCmd(options))
+
+	args := []string{"cluster", "target", "-a", "app", "--account", "prod", "--region", "us-east-1", "--target", "previous", "app-main", "-o", "table", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	expected := `NAME            REGION      DISABLED   CREATED
+app-main-v001   us-east-1   true       2020-06-01T12:00:00Z`
+	recieved := strings.TrimSpace(buffer.String())
+	if expected != recieved {
+		t.Fatalf("Unexpected table output:\n%s", recieved)
+	}
+}
+
+func TestClusterTarget_invalidTarget(t *testing.T) {
+	ts := testGateClusterTargetSuccess()
+	defer ts.Close()
+
+	rootCmd, options := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewClusterCmd(options))
+
+	args := []string{"cluster", "target", "-a", "app", "--account", "prod", "--region", "us-east-1", "--target", "largest", "app-main", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Expected failure but command succeeded")
+	}
+}
+
+func TestClusterTarget_flags(t *testing.T) {
+	ts := testGateClusterTargetSuccess()
+	defer ts.Close()
+
+	rootCmd, options := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewClusterCmd(options))
+
+	args := []string{"cluster", "target", "-a", "app", "--account", "prod", "app-main", "--gate-endpoint=" + ts.URL} // Missing region.
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Expected failure but command succeeded")
+	}
+}
+
+// testGateClusterTargetSuccess spins up a local http server that we will configure the GateClient
+// to direct requests to. Responds with the previous server group of the cluster app-main.
+func testGateClusterTargetSuccess() *httptest.Server {
+	mux := util.TestGateMuxWithVersionHandler()
+	mux.Handle("/applications/app/clusters/prod/app-main/aws/us-east-1/serverGroups/target/ancestor_asg_dynamic", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"name": "app-main-v001", "region": "us-east-1", "disabled": true, "createdTime": 1591012800000}`)
+	}))
+	return httptest.NewServer(mux)
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package config
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd"
+)
+
+type configOptions struct {
+	*cmd.RootOptions
+}
+
+var (
+	configShort   = "Manage the spin config file"
+	configLong    = "Manage the named contexts in the spin config file"
+	configExample = ""
+)
+
+func NewConfigCmd(rootOptions *cmd.RootOptions) *cobra.Command {
+	options := &configOptions{
+		RootOptions: rootOptions,
+	}
+	cmd := &cobra.Command{
+		Use:     "config",
+		Aliases: []string{},
+		Short:   configShort,
+		Long:    configLong,
+		Example: configExample,
+		Annotations: map[string]string{
+			cmd.LocalOnlyAnnotation: "",
+		},
+	}
+
+	// create subcommands
+	cmd.AddCommand(NewGetContextsCmd(options))
+	cmd.AddCommand(NewUseContextCmd(options))
+	return cmd
+}
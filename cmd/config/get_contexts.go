@@ -0,0 +1,78 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+)
+
+type getContextsOptions struct {
+	*configOptions
+}
+
+var (
+	getContextsShort   = "List the contexts in the spin config file"
+	getContextsLong    = "List the contexts in the spin config file, marking the current context"
+	getContextsExample = "usage: spin config get-contexts [options]"
+)
+
+func NewGetContextsCmd(configOptions *configOptions) *cobra.Command {
+	options := &getContextsOptions{
+		configOptions: configOptions,
+	}
+	cmd := &cobra.Command{
+		Use:     "get-contexts",
+		Short:   getContextsShort,
+		Long:    getContextsLong,
+		Example: getContextsExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return getContexts(cmd, options)
+		},
+	}
+	return cmd
+}
+
+func getContexts(cmd *cobra.Command, options *getContextsOptions) error {
+	location, err := gateclient.ConfigLocation(options.Ui, options.ConfigPath())
+	if err != nil {
+		return err
+	}
+	cfg, err := gateclient.LoadConfig(options.Ui, location)
+	if err != nil {
+		return err
+	}
+
+	buf := new(bytes.Buffer)
+	w := tabwriter.NewWriter(buf, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "CURRENT\tNAME\tENDPOINT\tAPPLICATION")
+	for _, name := range cfg.ContextNames() {
+		ctx := cfg.Contexts[name]
+		current := ""
+		if name == cfg.CurrentContext {
+			current = "*"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", current, name, ctx.Gate.Endpoint, ctx.DefaultApplication)
+	}
+	w.Flush()
+
+	options.Ui.Output(strings.TrimSpace(buf.String()))
+	return nil
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/andreyvit/diff"
+	"github.com/spinnaker/spin/cmd"
+)
+
+func TestGetContexts_basic(t *testing.T) {
+	tempFile := tempConfigFile(testConfig)
+	if tempFile == nil {
+		t.Fatal("Could not create temp config file.")
+	}
+	defer os.Remove(tempFile.Name())
+
+	buffer := new(bytes.Buffer)
+	rootCmd, rootOpts := cmd.NewCmdRoot(buffer, buffer)
+	rootCmd.AddCommand(NewConfigCmd(rootOpts))
+
+	args := []string{"config", "get-contexts", "--config", tempFile.Name()}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	expected := strings.TrimSpace(getContextsOutput)
+	recieved := strings.TrimSpace(buffer.String())
+	if expected != recieved {
+		t.Fatalf("Unexpected command output:\n%s", diff.LineDiff(expected, recieved))
+	}
+}
+
+const getContextsOutput = `
+CURRENT   NAME   ENDPOINT                        APPLICATION
+*         dev    https://gate.dev.example.com    app
+          prod   https://gate.prod.example.com
+`
@@ -0,0 +1,77 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+	"github.com/spinnaker/spin/util"
+)
+
+type useContextOptions struct {
+	*configOptions
+}
+
+var (
+	useContextShort   = "Set the current context in the spin config file"
+	useContextLong    = "Set the current context in the spin config file"
+	useContextExample = "usage: spin config use-context [options] context-name"
+)
+
+func NewUseContextCmd(configOptions *configOptions) *cobra.Command {
+	options := &useContextOptions{
+		configOptions: configOptions,
+	}
+	cmd := &cobra.Command{
+		Use:     "use-context",
+		Short:   useContextShort,
+		Long:    useContextLong,
+		Example: useContextExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return useContext(cmd, options, args)
+		},
+	}
+	return cmd
+}
+
+func useContext(cmd *cobra.Command, options *useContextOptions, args []string) error {
+	name, err := util.ReadArgsOrStdin(args)
+	if err != nil {
+		return err
+	}
+
+	location, err := gateclient.ConfigLocation(options.Ui, options.ConfigPath())
+	if err != nil {
+		return err
+	}
+	cfg, err := gateclient.LoadConfig(options.Ui, location)
+	if err != nil {
+		return err
+	}
+
+	if err := cfg.UseContext(name); err != nil {
+		return err
+	}
+	// Only the currentContext key is rewritten, since the loaded config has
+	// environment variables expanded and credentials resolved.
+	if err := gateclient.SetCurrentContext(location, name); err != nil {
+		return fmt.Errorf("Could not write config file %s: %v\n", location, err)
+	}
+
+	options.Ui.Success(fmt.Sprintf("Switched to context %q", name))
+	return nil
+}
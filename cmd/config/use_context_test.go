@@ -0,0 +1,99 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spinnaker/spin/cmd"
+)
+
+func TestUseContext_basic(t *testing.T) {
+	os.Setenv("GATE_PW", "s3cret")
+	defer os.Unsetenv("GATE_PW")
+
+	tempFile := tempConfigFile(testConfig)
+	if tempFile == nil {
+		t.Fatal("Could not create temp config file.")
+	}
+	defer os.Remove(tempFile.Name())
+
+	rootCmd, rootOpts := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewConfigCmd(rootOpts))
+
+	args := []string{"config", "use-context", "prod", "--config", tempFile.Name()}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	written, err := ioutil.ReadFile(tempFile.Name())
+	if err != nil {
+		t.Fatalf("Could not read config file: %v", err)
+	}
+	expected := strings.Replace(testConfig, "currentContext: dev", "currentContext: prod", 1)
+	if string(written) != expected {
+		t.Fatalf("Expected only the current context to change in config file:\n%s", written)
+	}
+}
+
+func TestUseContext_missing(t *testing.T) {
+	tempFile := tempConfigFile(testConfig)
+	if tempFile == nil {
+		t.Fatal("Could not create temp config file.")
+	}
+	defer os.Remove(tempFile.Name())
+
+	rootCmd, rootOpts := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewConfigCmd(rootOpts))
+
+	args := []string{"config", "use-context", "qa", "--config", tempFile.Name()}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+}
+
+func tempConfigFile(content string) *os.File {
+	tempFile, _ := ioutil.TempFile("" /* /tmp dir. */, "spin-config")
+	bytes, err := tempFile.Write([]byte(content))
+	if err != nil || bytes == 0 {
+		return nil
+	}
+	return tempFile
+}
+
+const testConfig = `
+# Contexts of the deploy team.
+currentContext: dev # switched with use-context
+contexts:
+  dev:
+    gate:
+      endpoint: https://gate.dev.example.com
+    defaultApplication: app
+  prod:
+    gate:
+      endpoint: https://gate.prod.example.com
+    auth:
+      enabled: true
+      basic:
+        username: user
+        password: ${GATE_PW}
+`
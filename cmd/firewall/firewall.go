@@ -0,0 +1,48 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package firewall
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd"
+)
+
+type firewallOptions struct {
+	*cmd.RootOptions
+}
+
+var (
+	firewallShort   = ""
+	firewallLong    = "Firewalls are known as security groups by some cloud providers."
+	firewallExample = ""
+)
+
+func NewFirewallCmd(rootOptions *cmd.RootOptions) *cobra.Command {
+	options := &firewallOptions{
+		RootOptions: rootOptions,
+	}
+	cmd := &cobra.Command{
+		Use:     "firewall",
+		Aliases: []string{"firewalls", "security-group", "security-groups"},
+		Short:   firewallShort,
+		Long:    firewallLong,
+		Example: firewallExample,
+	}
+
+	// create subcommands
+	cmd.AddCommand(NewGetCmd(options))
+	cmd.AddCommand(NewListCmd(options))
+	return cmd
+}
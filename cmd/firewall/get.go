@@ -0,0 +1,100 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package firewall
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+	"github.com/spinnaker/spin/util"
+)
+
+type getOptions struct {
+	*firewallOptions
+	account  string
+	region   string
+	provider string
+	vpcId    string
+}
+
+var (
+	getFirewallShort   = "Get the specified firewall"
+	getFirewallLong    = "Get the details, including the rules, of the specified firewall"
+	getFirewallExample = "usage: spin firewall get [options] firewall-name"
+)
+
+func NewGetCmd(firewallOptions *firewallOptions) *cobra.Command {
+	options := &getOptions{
+		firewallOptions: firewallOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "get",
+		Short:   getFirewallShort,
+		Long:    getFirewallLong,
+		Example: getFirewallExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return getFirewall(cmd, options, args)
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&options.account, "account", "", "account of the firewall")
+	cmd.PersistentFlags().StringVar(&options.region, "region", "", "region of the firewall")
+	cmd.PersistentFlags().StringVar(&options.provider, "provider", "", "cloud provider of the firewall (default aws)")
+	cmd.PersistentFlags().StringVar(&options.vpcId, "vpc-id", "", "VPC of the firewall, for providers that allow the same name in several VPCs")
+
+	return cmd
+}
+
+func getFirewall(cmd *cobra.Command, options *getOptions, args []string) error {
+	name, err := util.ReadArgsOrStdin(args)
+	if err != nil {
+		return err
+	}
+	if options.account == "" || options.region == "" {
+		return errors.New("one of required parameters 'account' or 'region' not set")
+	}
+
+	query := map[string]interface{}{}
+	if options.provider != "" {
+		query["provider"] = options.provider
+	}
+	if options.vpcId != "" {
+		query["vpcId"] = options.vpcId
+	}
+
+	firewall, resp, err := options.GateClient.FirewallControllerApi.GetSecurityGroupUsingGET(options.GateClient.Context,
+		options.account,
+		name,
+		options.region,
+		query)
+	if resp != nil {
+		if resp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("Firewall '%s' not found in %s/%s\n", name, options.account, options.region)
+		} else if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("Encountered an error getting firewall %s, %v\n", name, gateclient.ResponseError(resp, err))
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	options.Ui.JsonOutput(firewall)
+	return nil
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package firewall
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spinnaker/spin/cmd"
+	"github.com/spinnaker/spin/util"
+)
+
+func TestFirewallGet_basic(t *testing.T) {
+	ts := testGateFirewallGetSuccess()
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, options := cmd.NewCmdRoot(buffer, ioutil.Discard)
+	rootCmd.AddCommand(NewFirewallCmd(options))
+
+	args := []string{"firewall", "get", "--account", "prod", "--region", "us-east-1", "--vpc-id", "vpc-0001", "web", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	if !strings.Contains(buffer.String(), `"portRanges"`) {
+		t.Fatalf("Expected the firewall rules in output:\n%s", buffer.String())
+	}
+}
+
+func TestFirewallGet_flags(t *testing.T) {
+	ts := testGateFirewallGetSuccess()
+	defer ts.Close()
+
+	rootCmd, options := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewFirewallCmd(options))
+
+	args := []string{"firewall", "get", "--region", "us-east-1", "web", "--gate-endpoint=" + ts.URL} // Missing account.
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Expected failure but command succeeded")
+	}
+}
+
+func TestFirewallGet_notfound(t *testing.T) {
+	ts := testGateFirewallGetSuccess()
+	defer ts.Close()
+
+	rootCmd, options := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewFirewallCmd(options))
+
+	args := []string{"firewall", "get", "--account", "prod", "--region", "us-east-1", "missing", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("Expected not found error, got: %v", err)
+	}
+}
+
+// testGateFirewallGetSuccess spins up a local http server that we will configure the GateClient
+// to direct requests to. Responds with the firewall web in vpc-0001 and a 404 for any other.
+func testGateFirewallGetSuccess() *httptest.Server {
+	mux := util.TestGateMuxWithVersionHandler()
+	mux.Handle("/firewalls/prod/us-east-1/web", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("vpcId") != "vpc-0001" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintln(w, strings.TrimSpace(firewallJson))
+	}))
+	mux.Handle("/firewalls/prod/us-east-1/", http.NotFoundHandler())
+	return httptest.NewServer(mux)
+}
+
+const firewallJson = `
+{
+  "name": "web",
+  "id": "sg-0002",
+  "vpcId": "vpc-0001",
+  "accountName": "prod",
+  "region": "us-east-1",
+  "inboundRules": [
+    {"protocol": "tcp", "range": {"ip": "0.0.0.0", "cidr": "/0"}, "portRanges": [{"startPort": 443, "endPort": 443}]}
+  ]
+}
+`
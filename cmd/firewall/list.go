@@ -0,0 +1,176 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package firewall
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+	"github.com/spinnaker/spin/cmd/output"
+)
+
+type listOptions struct {
+	*firewallOptions
+	account  string
+	region   string
+	provider string
+}
+
+var (
+	listFirewallShort   = "List firewalls"
+	listFirewallLong    = "List firewalls, optionally only those in an account, region and cloud provider"
+	listFirewallExample = "usage: spin firewall list [options]"
+)
+
+// firewallColumns are the columns of table output.
+var firewallColumns = []output.Column{
+	{Header: "NAME", Path: "{.name}"},
+	{Header: "ACCOUNT", Path: "{.account}"},
+	{Header: "REGION", Path: "{.region}"},
+	{Header: "ID", Path: "{.id}"},
+	{Header: "VPC", Path: "{.vpcId}", Wide: true},
+	{Header: "PROVIDER", Path: "{.provider}", Wide: true},
+}
+
+func NewListCmd(firewallOptions *firewallOptions) *cobra.Command {
+	options := &listOptions{
+		firewallOptions: firewallOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   listFirewallShort,
+		Long:    listFirewallLong,
+		Example: listFirewallExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listFirewalls(cmd, options)
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&options.account, "account", "", "only list the firewalls in this account")
+	cmd.PersistentFlags().StringVar(&options.region, "region", "", "only list the firewalls in this region")
+	cmd.PersistentFlags().StringVar(&options.provider, "provider", "", "only list the firewalls of this cloud provider")
+
+	return cmd
+}
+
+func listFirewalls(cmd *cobra.Command, options *listOptions) error {
+	var firewalls []map[string]interface{}
+	var resp *http.Response
+	var err error
+	switch {
+	case options.account != "" && options.region != "":
+		// Gate lists the firewalls of a single provider, aws unless set.
+		query := map[string]interface{}{}
+		if options.provider != "" {
+			query["provider"] = options.provider
+		}
+		var list []interface{}
+		list, resp, err = options.GateClient.FirewallControllerApi.AllByAccountAndRegionUsingGET(options.GateClient.Context, options.account, options.region, query)
+		firewalls = flattenFirewalls(list, []string{"account", "region"}, []string{options.account, options.region})
+	case options.account != "":
+		// Grouped by provider and region.
+		var byProvider interface{}
+		byProvider, resp, err = options.GateClient.FirewallControllerApi.AllByAccountUsingGET(options.GateClient.Context, options.account, map[string]interface{}{})
+		firewalls = flattenFirewalls(byProvider, []string{"account", "provider", "region"}, []string{options.account})
+	default:
+		// Grouped by account, provider and region.
+		var byAccount interface{}
+		byAccount, resp, err = options.GateClient.FirewallControllerApi.AllUsingGET1(options.GateClient.Context, map[string]interface{}{})
+		firewalls = flattenFirewalls(byAccount, []string{"account", "provider", "region"}, nil)
+	}
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Encountered an error listing firewalls, %v\n", gateclient.ResponseError(resp, err))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	filtered := []map[string]interface{}{}
+	for _, firewall := range firewalls {
+		if options.region != "" && firewall["region"] != options.region {
+			continue
+		}
+		if options.provider != "" && firewall["provider"] != nil && firewall["provider"] != options.provider {
+			continue
+		}
+		filtered = append(filtered, firewall)
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return sortKey(filtered[i]) < sortKey(filtered[j])
+	})
+
+	options.Ui.TableOutput(filtered, firewallColumns)
+	return nil
+}
+
+// flattenFirewalls lists the firewalls in Gate's response, which groups them
+// in maps keyed by the fields in levels. The values of the first levels may
+// already be known from the request, the remaining ones are the map keys. The
+// fields are set on each of the listed firewalls.
+func flattenFirewalls(grouped interface{}, levels []string, known []string) []map[string]interface{} {
+	firewalls := []map[string]interface{}{}
+	var walk func(value interface{}, depth int, fields map[string]interface{})
+	walk = func(value interface{}, depth int, fields map[string]interface{}) {
+		if depth < len(known) {
+			fields[levels[depth]] = known[depth]
+			walk(value, depth+1, fields)
+			return
+		}
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if depth >= len(levels) {
+				return
+			}
+			for key, nested := range v {
+				next := copyFields(fields)
+				next[levels[depth]] = key
+				walk(nested, depth+1, next)
+			}
+		case []interface{}:
+			for _, item := range v {
+				summary, ok := item.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				firewall := copyFields(summary)
+				for field, value := range fields {
+					firewall[field] = value
+				}
+				firewalls = append(firewalls, firewall)
+			}
+		}
+	}
+	walk(grouped, 0, map[string]interface{}{})
+	return firewalls
+}
+
+// sortKey orders firewalls by account, region and name.
+func sortKey(firewall map[string]interface{}) string {
+	return fmt.Sprintf("%v\x00%v\x00%v", firewall["account"], firewall["region"], firewall["name"])
+}
+
+func copyFields(fields map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		copied[k] = v
+	}
+	return copied
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package firewall
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spinnaker/spin/cmd"
+	"github.com/spinnaker/spin/util"
+)
+
+func TestFirewallList_all(t *testing.T) {
+	ts := testGateFirewallListSuccess()
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, options := cmd.NewCmdRoot(buffer, buffer)
+	rootCmd.AddCommand(NewFirewallCmd(options))
+
+	args := []string{"firewall", "list", "-o", "wide", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	expected := `NAME      ACCOUNT   REGION      ID            VPC        PROVIDER
+default   prod      us-east-1   sg-0001       vpc-0001   aws
+web       prod      us-east-1   sg-0002       vpc-0001   aws
+default   prod      us-west-2   sg-0003       vpc-0002   aws
+web       test      global      web-ingress              gce`
+	recieved := strings.TrimSpace(buffer.String())
+	if expected != recieved {
+		t.Fatalf("Unexpected table output:\n%s", recieved)
+	}
+}
+
+func TestFirewallList_account(t *testing.T) {
+	ts := testGateFirewallListSuccess()
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, options := cmd.NewCmdRoot(buffer, buffer)
+	rootCmd.AddCommand(NewFirewallCmd(options))
+
+	args := []string{"firewall", "list", "--account", "prod", "--provider", "aws", "-o", "table", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	expected := `NAME      ACCOUNT   REGION      ID
+default   prod      us-east-1   sg-0001
+web       prod      us-east-1   sg-0002
+default   prod      us-west-2   sg-0003`
+	recieved := strings.TrimSpace(buffer.String())
+	if expected != recieved {
+		t.Fatalf("Unexpected table output:\n%s", recieved)
+	}
+}
+
+func TestFirewallList_accountAndRegion(t *testing.T) {
+	ts := testGateFirewallListSuccess()
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, options := cmd.NewCmdRoot(buffer, buffer)
+	rootCmd.AddCommand(NewFirewallCmd(options))
+
+	args := []string{"firewall", "list", "--account", "prod", "--region", "us-west-2", "-o", "table", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	expected := `NAME      ACCOUNT   REGION      ID
+default   prod      us-west-2   sg-0003`
+	recieved := strings.TrimSpace(buffer.String())
+	if expected != recieved {
+		t.Fatalf("Unexpected table output:\n%s", recieved)
+	}
+}
+
+func TestFirewallList_fail(t *testing.T) {
+	ts := testGateFail()
+	defer ts.Close()
+
+	rootCmd, options := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewFirewallCmd(options))
+
+	args := []string{"firewall", "list", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Expected failure but command succeeded")
+	}
+}
+
+// testGateFirewallListSuccess spins up a local http server that we will configure the GateClient
+// to direct requests to. Responds with the firewalls grouped as Gate does for each endpoint.
+func testGateFirewallListSuccess() *httptest.Server {
+	mux := util.TestGateMuxWithVersionHandler()
+	mux.Handle("/firewalls", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"prod": %s, "test": {"gce": {"global": [{"name": "web", "id": "web-ingress"}]}}}`, prodFirewallsJson)
+	}))
+	mux.Handle("/firewalls/prod", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, prodFirewallsJson)
+	}))
+	mux.Handle("/firewalls/prod/us-west-2", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `[{"name": "default", "id": "sg-0003", "vpcId": "vpc-0002"}]`)
+	}))
+	return httptest.NewServer(mux)
+}
+
+// testGateFail spins up a local http server that we will configure the GateClient
+// to direct requests to. Responds with a 500 InternalServerError.
+func testGateFail() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}))
+}
+
+const prodFirewallsJson = `{
+  "aws": {
+    "us-east-1": [
+      {"name": "web", "id": "sg-0002", "vpcId": "vpc-0001"},
+      {"name": "default", "id": "sg-0001", "vpcId": "vpc-0001"}
+    ],
+    "us-west-2": [
+      {"name": "default", "id": "sg-0003", "vpcId": "vpc-0002"}
+    ]
+  }
+}`
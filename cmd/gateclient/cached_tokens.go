@@ -0,0 +1,247 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package gateclient
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/spinnaker/spin/config"
+	"github.com/spinnaker/spin/config/auth"
+	"github.com/spinnaker/spin/config/credentials"
+	"sigs.k8s.io/yaml"
+)
+
+// cachedTokenMethods are the config keys of the authentication methods that
+// cache a token.
+var cachedTokenMethods = []string{"oauth2", "googleServiceAccount", "exec"}
+
+// cachedTokenKeys are the keys holding a cached token or its reference in the
+// credential store.
+var cachedTokenKeys = []string{"cachedToken", "cachedTokenRef"}
+
+// RemoveCachedTokens removes the cached OAuth2, Google service account and
+// exec tokens of the named context, or of the current context if name is
+// empty, from the spin config file at location and from the credential store.
+// Only those keys are removed: the rest of the file, including comments,
+// other contexts and unexpanded environment variables, is written back as it
+// was.
+func RemoveCachedTokens(location, name string) error {
+	info, err := os.Stat(location)
+	if err != nil {
+		return err
+	}
+	raw, err := ioutil.ReadFile(location)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := parseRawConfig(raw)
+	if err != nil {
+		return err
+	}
+	if name == "" {
+		name = cfg.CurrentContext
+	}
+	ctx, err := cfg.ResolveContext(name)
+	if err != nil {
+		return err
+	}
+	refs := cachedTokenRefs(ctx.Auth)
+
+	// Clearing the tokens turns cfg into the config expected after the edit.
+	// There is nothing to do if that does not change it.
+	original, err := parseRawConfig(raw)
+	if err != nil {
+		return err
+	}
+	clearCachedTokens(ctx.Auth)
+	if reflect.DeepEqual(cfg, original) {
+		return nil
+	}
+
+	path := []string{"auth"}
+	if name != "" {
+		path = []string{"contexts", name, "auth"}
+	}
+	lines := strings.SplitAfter(string(raw), "\n")
+	var blocks []yamlBlock
+	if authBlock, ok := findYamlBlock(lines, 0, len(lines), path...); ok {
+		for _, method := range cachedTokenMethods {
+			methodBlock, ok := findYamlBlock(lines, authBlock.key+1, authBlock.end, method)
+			if !ok {
+				continue
+			}
+			for _, key := range cachedTokenKeys {
+				if block, ok := findYamlBlock(lines, methodBlock.key+1, methodBlock.end, key); ok {
+					blocks = append(blocks, block)
+				}
+			}
+		}
+	}
+	// Remove the blocks last to first so that the earlier lines keep their
+	// indexes.
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i].key > blocks[j].key })
+	for _, block := range blocks {
+		lines = append(lines[:block.key], lines[block.end:]...)
+	}
+	edited := strings.Join(lines, "")
+
+	// Make sure the edit removed the cached tokens and nothing else before
+	// replacing the file.
+	editedCfg, err := parseRawConfig([]byte(edited))
+	if err != nil || !reflect.DeepEqual(cfg, editedCfg) {
+		return fmt.Errorf("could not remove the cached tokens from %s, remove them by hand", location)
+	}
+
+	if len(refs) > 0 {
+		store, err := credentialStore(cfg, location)
+		if err != nil {
+			return err
+		}
+		for _, ref := range refs {
+			if store == nil {
+				break
+			}
+			if err := store.Erase(ref); err != nil && err != credentials.ErrNotFound {
+				return err
+			}
+		}
+	}
+
+	return ioutil.WriteFile(location, []byte(edited), info.Mode())
+}
+
+func parseRawConfig(raw []byte) (*config.Config, error) {
+	cfg := &config.Config{}
+	if err := yaml.Unmarshal([]byte(os.ExpandEnv(string(raw))), cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// cachedTokenRefs returns the credential store references of the cached
+// tokens.
+func cachedTokenRefs(a *auth.Config) []string {
+	var refs []string
+	if a == nil {
+		return refs
+	}
+	if a.OAuth2 != nil && a.OAuth2.CachedTokenRef != "" {
+		refs = append(refs, a.OAuth2.CachedTokenRef)
+	}
+	if a.GoogleServiceAccount != nil && a.GoogleServiceAccount.CachedTokenRef != "" {
+		refs = append(refs, a.GoogleServiceAccount.CachedTokenRef)
+	}
+	if a.Exec != nil && a.Exec.CachedTokenRef != "" {
+		refs = append(refs, a.Exec.CachedTokenRef)
+	}
+	return refs
+}
+
+// clearCachedTokens clears the cached tokens and their references.
+func clearCachedTokens(a *auth.Config) {
+	if a == nil {
+		return
+	}
+	a.ClearCachedTokens()
+	if a.OAuth2 != nil {
+		a.OAuth2.CachedTokenRef = ""
+	}
+	if a.GoogleServiceAccount != nil {
+		a.GoogleServiceAccount.CachedTokenRef = ""
+	}
+	if a.Exec != nil {
+		a.Exec.CachedTokenRef = ""
+	}
+}
+
+// yamlBlock is a key of a block mapping and its value, as the line of the key
+// and the line after the last line of the value.
+type yamlBlock struct {
+	key int
+	end int
+}
+
+// findYamlBlock finds the block of the nested keys in the block mapping
+// spanning lines[start:end]. Keys are matched case-insensitively, as when
+// the config is parsed.
+func findYamlBlock(lines []string, start, end int, keys ...string) (yamlBlock, bool) {
+	block := yamlBlock{key: start - 1, end: end}
+	for _, key := range keys {
+		var ok bool
+		if block, ok = findYamlKey(lines, block.key+1, block.end, key); !ok {
+			return block, false
+		}
+	}
+	return block, true
+}
+
+func findYamlKey(lines []string, start, end int, key string) (yamlBlock, bool) {
+	indent := -1
+	for i := start; i < end; i++ {
+		if yamlBlank(lines[i]) {
+			continue
+		}
+		if indent < 0 {
+			indent = yamlIndent(lines[i])
+		}
+		if yamlIndent(lines[i]) != indent || !strings.EqualFold(yamlKey(lines[i]), key) {
+			continue
+		}
+
+		// The value ends at the next line indented no deeper than the key,
+		// other than the items of a sequence, which may be indented as much
+		// as their key.
+		last := i
+		for j := i + 1; j < end; j++ {
+			if yamlBlank(lines[j]) {
+				continue
+			}
+			trimmed := strings.TrimSpace(lines[j])
+			if yamlIndent(lines[j]) < indent ||
+				(yamlIndent(lines[j]) == indent && !strings.HasPrefix(trimmed, "- ") && trimmed != "-") {
+				break
+			}
+			last = j
+		}
+		return yamlBlock{key: i, end: last + 1}, true
+	}
+	return yamlBlock{}, false
+}
+
+// yamlBlank reports whether the line is empty or only a comment.
+func yamlBlank(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed == "" || strings.HasPrefix(trimmed, "#")
+}
+
+func yamlIndent(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// yamlKey returns the key of a mapping line, without quotes.
+func yamlKey(line string) string {
+	trimmed := strings.TrimSpace(line)
+	colon := strings.Index(trimmed, ":")
+	if colon < 0 {
+		return ""
+	}
+	return strings.Trim(trimmed[:colon], `"'`)
+}
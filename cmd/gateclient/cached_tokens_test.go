@@ -0,0 +1,140 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package gateclient
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spinnaker/spin/config/credentials"
+)
+
+const cachedTokenBlock = `    cachedToken:
+      access_token: access
+      expiry: "2999-01-01T00:00:00Z"
+`
+
+func TestRemoveCachedTokens(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   string
+		context  string
+		expected string
+	}{
+		{
+			name:     "top-level",
+			config:   "auth:\n  enabled: true\n  oauth2:\n    clientId: spin\n" + cachedTokenBlock + "    scopes:\n    - email\n",
+			expected: "auth:\n  enabled: true\n  oauth2:\n    clientId: spin\n    scopes:\n    - email\n",
+		},
+		{
+			name: "current context",
+			config: "currentContext: dev\ncontexts:\n  dev:\n    auth:\n      exec:\n        command: token # prints a token\n        cachedToken: {access_token: dev}\n" +
+				"  prod:\n    auth:\n      exec:\n        command: token\n        cachedToken: {access_token: prod}\n",
+			expected: "currentContext: dev\ncontexts:\n  dev:\n    auth:\n      exec:\n        command: token # prints a token\n" +
+				"  prod:\n    auth:\n      exec:\n        command: token\n        cachedToken: {access_token: prod}\n",
+		},
+		{
+			name:     "named context",
+			config:   "currentContext: dev\ncontexts:\n  dev:\n    auth:\n      exec:\n        cachedToken: {access_token: dev}\n  prod:\n    gate:\n      endpoint: ${GATE_URL}\n    auth:\n      exec:\n        cachedToken: {access_token: prod}\n        command: token\n",
+			context:  "prod",
+			expected: "currentContext: dev\ncontexts:\n  dev:\n    auth:\n      exec:\n        cachedToken: {access_token: dev}\n  prod:\n    gate:\n      endpoint: ${GATE_URL}\n    auth:\n      exec:\n        command: token\n",
+		},
+		{
+			name:     "nothing cached",
+			config:   "# spin config\nauth:\n  basic:\n    password: ${GATE_PW}\n",
+			expected: "# spin config\nauth:\n  basic:\n    password: ${GATE_PW}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			location := tempConfig(t, tt.config)
+			defer os.Remove(location)
+
+			if err := RemoveCachedTokens(location, tt.context); err != nil {
+				t.Fatalf("RemoveCachedTokens failed: %v", err)
+			}
+			written, err := ioutil.ReadFile(location)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(written) != tt.expected {
+				t.Fatalf("Unexpected config file:\n%s", written)
+			}
+		})
+	}
+}
+
+func TestRemoveCachedTokens_stored(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spin-config")
+	if err != nil {
+		t.Fatalf("Could not create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	location := filepath.Join(dir, "config")
+	config := "credentialHelper: file\nauth:\n  oauth2:\n    clientId: spin\n    cachedTokenRef: spin://config/oauth2\n"
+	if err := ioutil.WriteFile(location, []byte(config), 0600); err != nil {
+		t.Fatalf("Could not write config file: %v", err)
+	}
+	store := credentials.NewFileStore(dir)
+	if err := store.Store(&credentials.Credentials{ServerURL: "spin://config/oauth2", Username: "token", Secret: "{}"}); err != nil {
+		t.Fatalf("Could not store token: %v", err)
+	}
+
+	if err := RemoveCachedTokens(location, ""); err != nil {
+		t.Fatalf("RemoveCachedTokens failed: %v", err)
+	}
+	written, err := ioutil.ReadFile(location)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(written) != "credentialHelper: file\nauth:\n  oauth2:\n    clientId: spin\n" {
+		t.Fatalf("Unexpected config file:\n%s", written)
+	}
+	if _, err := store.Get("spin://config/oauth2"); err != credentials.ErrNotFound {
+		t.Fatalf("Stored token not erased: %v", err)
+	}
+}
+
+func TestRemoveCachedTokens_flowStyle(t *testing.T) {
+	config := "auth: {oauth2: {clientId: spin, cachedToken: {access_token: access}}}\n"
+	location := tempConfig(t, config)
+	defer os.Remove(location)
+
+	if err := RemoveCachedTokens(location, ""); err == nil {
+		t.Fatalf("Expected an error for a cached token that can't be removed")
+	}
+	written, err := ioutil.ReadFile(location)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(written) != config {
+		t.Fatalf("Config file changed despite the error:\n%s", written)
+	}
+}
+
+func tempConfig(t *testing.T, content string) string {
+	f, err := ioutil.TempFile("", "spin-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(content); err != nil {
+		t.Fatal(err)
+	}
+	return f.Name()
+}
@@ -33,10 +33,15 @@ import (
 	"path/filepath"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/spinnaker/spin/cmd/output"
 	"github.com/spinnaker/spin/config"
+	"github.com/spinnaker/spin/config/auth"
+	"github.com/spinnaker/spin/config/auth/bearer"
 	iap "github.com/spinnaker/spin/config/auth/iap"
+	authoauth2 "github.com/spinnaker/spin/config/auth/oauth2"
+	"github.com/spinnaker/spin/util/execcmd"
 	"github.com/spinnaker/spin/version"
 
 	"github.com/mitchellh/go-homedir"
@@ -56,6 +61,10 @@ const (
 	// the Unix file permissions u=rw,g=,o= so that config files with cached tokens, at least by
 	// default, are only readable by the user that owns the config file.
 	defaultConfigFileMode os.FileMode = 0600 // u=rw,g=,o=
+
+	// oauth2LoginTimeout is how long to wait for the user to authenticate in
+	// their browser during OAuth2 login.
+	oauth2LoginTimeout = 5 * time.Minute
 )
 
 // GatewayClient is the wrapper with authentication
@@ -70,12 +79,22 @@ type GatewayClient struct {
 	// Spin CLI configuration.
 	Config config.Config
 
+	// The context selected from Config. Its fields alias into Config so that
+	// cached tokens are written back to the context they came from.
+	activeContext *config.Context
+
 	// Context for OAuth2 access token.
 	Context context.Context
 
+	// The context the client was created with, canceled when the command
+	// times out or is interrupted.
+	baseContext context.Context
+
 	// This is the set of flags global to the command parser.
 	gateEndpoint string
 
+	contextName string
+
 	ignoreCertErrors bool
 
 	// Location of the spin config.
@@ -84,23 +103,35 @@ type GatewayClient struct {
 	// Raw Http Client to do OAuth2 login.
 	httpClient *http.Client
 
+	// Configuration of the generated API client, used for raw Gate requests.
+	apiConfig *gate.Configuration
+
 	ui output.Ui
 }
 
 func (m *GatewayClient) GateEndpoint() string {
-	if m.Config.Gate.Endpoint == "" && m.gateEndpoint == "" {
+	if m.activeContext.Gate.Endpoint == "" && m.gateEndpoint == "" {
 		return "http://localhost:8084"
 	}
 	if m.gateEndpoint != "" {
 		return m.gateEndpoint
 	}
-	return m.Config.Gate.Endpoint
+	return m.activeContext.Gate.Endpoint
+}
+
+// DefaultApplication returns the default application configured for the
+// active context, if any.
+func (m *GatewayClient) DefaultApplication() string {
+	return m.activeContext.DefaultApplication
 }
 
 // Create new spinnaker gateway client with flag
-func NewGateClient(ui output.Ui, gateEndpoint, defaultHeaders, configLocation string, ignoreCertErrors bool) (*GatewayClient, error) {
+func NewGateClient(ctx context.Context, ui output.Ui, gateEndpoint, defaultHeaders, configLocation, contextName string, ignoreCertErrors bool, retryAttempts int, retryPost bool, verbose int) (*GatewayClient, error) {
 	gateClient := &GatewayClient{
+		Context:          ctx,
+		baseContext:      ctx,
 		gateEndpoint:     gateEndpoint,
+		contextName:      contextName,
 		ignoreCertErrors: ignoreCertErrors,
 		ui:               ui,
 	}
@@ -117,6 +148,18 @@ func NewGateClient(ui output.Ui, gateEndpoint, defaultHeaders, configLocation st
 		return nil, err
 	}
 
+	// Trace every attempt of every request, including logins.
+	if verbose > 0 {
+		httpClient.Transport = &traceTransport{base: httpClient.Transport, level: verbose, ui: ui}
+	}
+
+	// Retry transient failures of every request, including logins.
+	retry, err := newRetryTransport(httpClient.Transport, ui, gateClient.Config.ResolveRetry(gateClient.activeContext), retryAttempts, retryPost)
+	if err != nil {
+		return nil, err
+	}
+	httpClient.Transport = retry
+
 	gateClient.httpClient = httpClient
 
 	err = gateClient.authenticateOAuth2()
@@ -137,6 +180,9 @@ func NewGateClient(ui output.Ui, gateEndpoint, defaultHeaders, configLocation st
 	}
 
 	m := make(map[string]string)
+	for k, v := range gateClient.activeContext.DefaultHeaders {
+		m[k] = v
+	}
 
 	if defaultHeaders != "" {
 		headers := strings.Split(defaultHeaders, ",")
@@ -156,6 +202,7 @@ func NewGateClient(ui output.Ui, gateEndpoint, defaultHeaders, configLocation st
 		HTTPClient:    httpClient,
 	}
 	gateClient.APIClient = gate.NewAPIClient(cfg)
+	gateClient.apiConfig = cfg
 
 	// TODO: Verify version compatibility between Spin CLI and Gate.
 	_, _, err = gateClient.VersionControllerApi.GetVersionUsingGET(gateClient.Context)
@@ -168,41 +215,99 @@ func NewGateClient(ui output.Ui, gateEndpoint, defaultHeaders, configLocation st
 }
 
 func userConfig(gateClient *GatewayClient, configLocation string) error {
+	location, err := ConfigLocation(gateClient.ui, configLocation)
+	if err != nil {
+		return err
+	}
+	gateClient.configLocation = location
+
+	cfg, err := LoadConfig(gateClient.ui, location)
+	if err != nil {
+		return err
+	}
+	gateClient.Config = *cfg
+
+	gateClient.activeContext, err = gateClient.Config.ResolveContext(gateClient.contextName)
+	if err != nil {
+		return err
+	}
+
+	// A token in the environment replaces the configured authentication. The
+	// context is copied so that the token is never written to the config file.
+	if token := os.Getenv(bearer.EnvToken); token != "" {
+		ctx := *gateClient.activeContext
+		ctx.Auth = &auth.Config{
+			Enabled: true,
+			Bearer:  &bearer.Config{Token: token},
+		}
+		gateClient.activeContext = &ctx
+	}
+	return nil
+}
+
+// ConfigLocation returns the path of the spin config file, defaulting to
+// $HOME/.spin/config when configLocation is empty.
+func ConfigLocation(ui output.Ui, configLocation string) (string, error) {
 	if configLocation != "" {
-		gateClient.configLocation = configLocation
-	} else {
-		userHome := ""
-		usr, err := user.Current()
-		if err != nil {
-			// Fallback by trying to read $HOME
-			userHome = os.Getenv("HOME")
-			if userHome != "" {
-				err = nil
-			} else {
-				gateClient.ui.Error("Could not read current user from environment, failing.")
-				return err
-			}
-		} else {
-			userHome = usr.HomeDir
+		return configLocation, nil
+	}
+
+	userHome := ""
+	usr, err := user.Current()
+	if err != nil {
+		// Fallback by trying to read $HOME
+		userHome = os.Getenv("HOME")
+		if userHome == "" {
+			ui.Error("Could not read current user from environment, failing.")
+			return "", err
 		}
-		gateClient.configLocation = filepath.Join(userHome, ".spin", "config")
+	} else {
+		userHome = usr.HomeDir
 	}
+	return filepath.Join(userHome, ".spin", "config"), nil
+}
 
-	yamlFile, err := ioutil.ReadFile(gateClient.configLocation)
+// LoadConfig reads the spin config file at location. A missing file yields
+// an empty config.
+func LoadConfig(ui output.Ui, location string) (*config.Config, error) {
+	cfg := &config.Config{}
+	yamlFile, _ := ioutil.ReadFile(location)
 	if yamlFile != nil {
-		err = yaml.UnmarshalStrict([]byte(os.ExpandEnv(string(yamlFile))), &gateClient.Config)
+		err := yaml.UnmarshalStrict([]byte(os.ExpandEnv(string(yamlFile))), cfg)
 		if err != nil {
-			gateClient.ui.Error(fmt.Sprintf("Could not deserialize config file with contents: %s, failing.", yamlFile))
-			return err
+			ui.Error(fmt.Sprintf("Could not deserialize config file with contents: %s, failing.", yamlFile))
+			return nil, err
 		}
-	} else {
-		gateClient.Config = config.Config{}
 	}
-	return nil
+	store, err := credentialStore(cfg, location)
+	if err != nil {
+		return nil, err
+	}
+	if err := resolveCredentials(store, cfg); err != nil {
+		ui.Error("Could not read credentials from the credential store, failing.")
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// WriteConfig writes cfg to the spin config file at location. With a
+// credential helper configured, secrets are written to the credential store
+// and the file only holds references to them.
+func WriteConfig(cfg *config.Config, location string) error {
+	store, err := credentialStore(cfg, location)
+	if err != nil {
+		return err
+	}
+	restore, err := externalizeCredentials(store, cfg)
+	defer restore()
+	if err != nil {
+		return err
+	}
+	return writeYAML(cfg, location, defaultConfigFileMode)
 }
 
 func (m *GatewayClient) initializeClient() (*http.Client, error) {
-	auth := m.Config.Auth
+	auth := m.activeContext.Auth
 	cookieJar, _ := cookiejar.New(nil)
 	client := http.Client{
 		Jar: cookieJar,
@@ -259,13 +364,24 @@ func (m *GatewayClient) initializeClient() (*http.Client, error) {
 		}
 	} else if auth != nil && auth.Enabled && auth.Iap != nil {
 		accessToken, err := m.authenticateIAP()
-		m.Context = context.WithValue(context.Background(), gate.ContextAccessToken, accessToken)
+		m.Context = context.WithValue(m.baseContext, gate.ContextAccessToken, accessToken)
+		return &client, err
+	} else if auth != nil && auth.Enabled && auth.Exec != nil {
+		accessToken, err := m.authenticateExec()
+		m.Context = context.WithValue(m.baseContext, gate.ContextAccessToken, accessToken)
+		return &client, err
+	} else if auth != nil && auth.Enabled && auth.Bearer != nil {
+		if !auth.Bearer.IsValid() {
+			return nil, errors.New("Incorrect bearer auth configuration. Must include token or tokenFile.")
+		}
+		accessToken, err := auth.Bearer.AccessToken()
+		m.Context = context.WithValue(m.baseContext, gate.ContextAccessToken, accessToken)
 		return &client, err
 	} else if auth != nil && auth.Enabled && auth.Basic != nil {
 		if !auth.Basic.IsValid() {
 			return nil, errors.New("Incorrect Basic auth configuration. Must include username and password.")
 		}
-		m.Context = context.WithValue(context.Background(), gate.ContextBasicAuth, gate.BasicAuth{
+		m.Context = context.WithValue(m.baseContext, gate.ContextBasicAuth, gate.BasicAuth{
 			UserName: auth.Basic.Username,
 			Password: auth.Basic.Password,
 		})
@@ -289,7 +405,7 @@ func (m *GatewayClient) initializeX509Config(client http.Client, clientCA []byte
 }
 
 func (m *GatewayClient) authenticateOAuth2() error {
-	auth := m.Config.Auth
+	auth := m.activeContext.Auth
 	if auth != nil && auth.Enabled && auth.OAuth2 != nil {
 		OAuth2 := auth.OAuth2
 		if !OAuth2.IsValid() {
@@ -300,7 +416,6 @@ func (m *GatewayClient) authenticateOAuth2() error {
 		config := &oauth2.Config{
 			ClientID:     OAuth2.ClientId,
 			ClientSecret: OAuth2.ClientSecret,
-			RedirectURL:  "http://localhost:8085",
 			Scopes:       OAuth2.Scopes,
 			Endpoint: oauth2.Endpoint{
 				AuthURL:  OAuth2.AuthUrl,
@@ -313,59 +428,134 @@ func (m *GatewayClient) authenticateOAuth2() error {
 		if auth.OAuth2.CachedToken != nil {
 			// Look up cached credentials to save oauth2 roundtrip.
 			token := auth.OAuth2.CachedToken
-			tokenSource := config.TokenSource(context.Background(), token)
+			tokenSource := config.TokenSource(m.baseContext, token)
 			newToken, err = tokenSource.Token()
 			if err != nil {
 				m.ui.Error(fmt.Sprintf("Could not refresh token from source: %v", tokenSource))
 				return err
 			}
-		} else {
-			// Do roundtrip.
-			http.Handle("/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				code := r.FormValue("code")
-				fmt.Fprintln(w, code)
-			}))
-			go http.ListenAndServe(":8085", nil)
-			// Note: leaving server connection open for scope of request, will be reaped on exit.
-
-			verifier, verifierCode, err := m.generateCodeVerifier()
+		} else if OAuth2.IsDeviceFlow() {
+			newToken, err = m.authenticateOAuth2Device(OAuth2)
 			if err != nil {
 				return err
 			}
-
-			codeVerifier := oauth2.SetAuthURLParam("code_verifier", verifier)
-			codeChallenge := oauth2.SetAuthURLParam("code_challenge", verifierCode)
-			challengeMethod := oauth2.SetAuthURLParam("code_challenge_method", "S256")
-
-			authURL := config.AuthCodeURL("state-token", oauth2.AccessTypeOffline, oauth2.ApprovalForce, challengeMethod, codeChallenge)
-			m.ui.Output(fmt.Sprintf("Navigate to %s and authenticate", authURL))
-			code := m.prompt("Paste authorization code:")
-
-			newToken, err = config.Exchange(context.Background(), code, codeVerifier)
+		} else {
+			newToken, err = m.authenticateOAuth2Browser(OAuth2, config)
 			if err != nil {
 				return err
 			}
 		}
 
-		m.ui.Info("Caching oauth2 token.")
-		OAuth2.CachedToken = newToken
-		_ = m.writeYAMLConfig()
+		// The token source returns the cached token itself while it is valid,
+		// in which case the config file is left alone.
+		if newToken != OAuth2.CachedToken {
+			m.ui.Info("Caching oauth2 token.")
+			OAuth2.CachedToken = newToken
+			_ = m.writeYAMLConfig()
+		}
 
 		m.login(newToken.AccessToken)
-		m.Context = context.Background()
+		m.Context = m.baseContext
 	}
 	return nil
 }
 
+// authenticateOAuth2Browser obtains a token with the OAuth2 authorization code
+// grant, receiving the code on a loopback listener once the user has
+// authenticated in their browser.
+func (m *GatewayClient) authenticateOAuth2Browser(OAuth2 *authoauth2.Config, config *oauth2.Config) (*oauth2.Token, error) {
+	receiver, err := authoauth2.NewLoopbackReceiver(OAuth2.CallbackPort)
+	if err != nil {
+		return nil, err
+	}
+	defer receiver.Close()
+	config.RedirectURL = receiver.RedirectURL()
+
+	verifier, verifierCode, err := m.generateCodeVerifier()
+	if err != nil {
+		return nil, err
+	}
+
+	codeVerifier := oauth2.SetAuthURLParam("code_verifier", verifier)
+	codeChallenge := oauth2.SetAuthURLParam("code_challenge", verifierCode)
+	challengeMethod := oauth2.SetAuthURLParam("code_challenge_method", "S256")
+
+	authURL := config.AuthCodeURL(receiver.State(), oauth2.AccessTypeOffline, oauth2.ApprovalForce, challengeMethod, codeChallenge)
+	if err := execcmd.OpenUrl(authURL); err != nil {
+		m.ui.Output(fmt.Sprintf("Navigate to %s and authenticate", authURL))
+	} else {
+		m.ui.Output(fmt.Sprintf("Your browser has been opened to visit %s", authURL))
+	}
+	m.ui.Info("Waiting for authorization...")
+
+	ctx, cancel := context.WithTimeout(m.baseContext, oauth2LoginTimeout)
+	defer cancel()
+	code, err := receiver.Wait(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return config.Exchange(m.baseContext, code, codeVerifier)
+}
+
+// authenticateOAuth2Device obtains a token with the OAuth2 device authorization
+// grant, which needs no browser or local listener on the host running spin.
+func (m *GatewayClient) authenticateOAuth2Device(OAuth2 *authoauth2.Config) (*oauth2.Token, error) {
+	deviceAuth, err := OAuth2.RequestDeviceAuthorization(m.baseContext, m.httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	if deviceAuth.VerificationUriComplete != "" {
+		m.ui.Output(fmt.Sprintf("Navigate to %s and confirm the code %s", deviceAuth.VerificationUriComplete, deviceAuth.UserCode))
+	} else {
+		m.ui.Output(fmt.Sprintf("Navigate to %s and enter the code %s", deviceAuth.VerificationTarget(), deviceAuth.UserCode))
+	}
+	m.ui.Info("Waiting for authorization...")
+
+	return OAuth2.PollDeviceToken(m.baseContext, m.httpClient, deviceAuth)
+}
+
 func (m *GatewayClient) authenticateIAP() (string, error) {
-	auth := m.Config.Auth
+	auth := m.activeContext.Auth
 	iapConfig := auth.Iap
 	token, err := iap.GetIapToken(*iapConfig)
 	return token, err
 }
 
+// authenticateExec returns the token printed by the exec auth command, reusing
+// the cached token until it expires.
+func (m *GatewayClient) authenticateExec() (string, error) {
+	execConfig := m.activeContext.Auth.Exec
+	if !execConfig.IsValid() {
+		return "", errors.New("Incorrect exec auth configuration. Must include command.")
+	}
+
+	if execConfig.CachedToken != nil && execConfig.CachedToken.Valid() {
+		return execConfig.CachedToken.AccessToken, nil
+	}
+
+	token, err := execConfig.Token()
+	if err != nil {
+		return "", err
+	}
+
+	// Tokens without an expiry can't be reused safely.
+	if token.Expiry.IsZero() {
+		if execConfig.CachedToken != nil {
+			execConfig.CachedToken = nil
+			_ = m.writeYAMLConfig()
+		}
+	} else {
+		m.ui.Info("Caching exec token.")
+		execConfig.CachedToken = token
+		_ = m.writeYAMLConfig()
+	}
+	return token.AccessToken, nil
+}
+
 func (m *GatewayClient) authenticateGoogleServiceAccount() (err error) {
-	auth := m.Config.Auth
+	auth := m.activeContext.Auth
 	if auth == nil {
 		return nil
 	}
@@ -382,7 +572,7 @@ func (m *GatewayClient) authenticateGoogleServiceAccount() (err error) {
 
 	var source oauth2.TokenSource
 	if gsa.File == "" {
-		source, err = google.DefaultTokenSource(context.Background(), "profile", "email")
+		source, err = google.DefaultTokenSource(m.baseContext, "profile", "email")
 	} else {
 		serviceAccountJSON, ferr := ioutil.ReadFile(gsa.File)
 		if ferr != nil {
@@ -404,7 +594,7 @@ func (m *GatewayClient) authenticateGoogleServiceAccount() (err error) {
 	}
 
 	gsa.CachedToken = token
-	m.Context = context.Background()
+	m.Context = m.baseContext
 
 	// Cache token if login succeeded
 	gsa.CachedToken = token
@@ -418,13 +608,14 @@ func (m *GatewayClient) login(accessToken string) error {
 	if err != nil {
 		return err
 	}
+	loginReq = loginReq.WithContext(m.baseContext)
 	loginReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
 	m.httpClient.Do(loginReq) // Login to establish session.
 	return nil
 }
 
 func (m *GatewayClient) authenticateLdap() error {
-	auth := m.Config.Auth
+	auth := m.activeContext.Auth
 	if auth != nil && auth.Enabled && auth.Ldap != nil {
 		if auth.Ldap.Username == "" {
 			auth.Ldap.Username = m.prompt("Username:")
@@ -443,10 +634,11 @@ func (m *GatewayClient) authenticateLdap() error {
 		form.Add("password", auth.Ldap.Password)
 
 		loginReq, err := http.NewRequest("POST", m.GateEndpoint()+"/login", strings.NewReader(form.Encode()))
-		loginReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 		if err != nil {
 			return err
 		}
+		loginReq = loginReq.WithContext(m.baseContext)
+		loginReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
 		_, err = m.httpClient.Do(loginReq) // Login to establish session.
 
@@ -454,7 +646,7 @@ func (m *GatewayClient) authenticateLdap() error {
 			return errors.New("ldap authentication failed")
 		}
 
-		m.Context = context.Background()
+		m.Context = m.baseContext
 	}
 
 	return nil
@@ -465,9 +657,9 @@ func (m *GatewayClient) authenticateLdap() error {
 func (m *GatewayClient) writeYAMLConfig() error {
 	// Write updated config file with u=rw,g=,o= permissions by default.
 	// The default permissions should only be used if the file no longer exists.
-	err := writeYAML(&m.Config, m.configLocation, defaultConfigFileMode)
+	err := WriteConfig(&m.Config, m.configLocation)
 	if err != nil {
-		m.ui.Warn(fmt.Sprintf("Error caching oauth2 token: %v", err))
+		m.ui.Warn(fmt.Sprintf("Error caching token: %v", err))
 	}
 	return err
 }
@@ -483,7 +675,7 @@ func writeYAML(v interface{}, dest string, defaultMode os.FileMode) error {
 	info, err := os.Stat(dest)
 	if err != nil && !os.IsNotExist(err) {
 		return nil
-	} else {
+	} else if err == nil {
 		// Preserve existing file mode
 		mode = info.Mode()
 	}
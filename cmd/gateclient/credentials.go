@@ -0,0 +1,204 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package gateclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spinnaker/spin/config"
+	"github.com/spinnaker/spin/config/credentials"
+	"golang.org/x/oauth2"
+)
+
+// credentialSlot is a secret in the config that may be kept in the credential
+// store, with accessors for the secret and its reference.
+type credentialSlot struct {
+	serverURL string
+	username  string
+	ref       *string
+	get       func() (string, error)
+	set       func(secret string) error
+}
+
+// credentialStore returns the store selected by the config's
+// credentialHelper, or nil if secrets are kept in the config file. A helper
+// that is not installed is an error rather than a reason to fall back to the
+// obfuscated file store, which is much weaker than an OS keychain.
+func credentialStore(cfg *config.Config, location string) (credentials.Store, error) {
+	switch name := cfg.CredentialHelper; {
+	case name == "":
+		return nil, nil
+	case name == credentials.FileStoreName:
+		return credentials.NewFileStore(filepath.Dir(location)), nil
+	case !credentials.HelperInstalled(name):
+		return nil, fmt.Errorf("credential helper %s is not installed; install it, or set credentialHelper to %q to keep credentials in an obfuscated file instead",
+			credentials.HelperProgram(name), credentials.FileStoreName)
+	default:
+		return credentials.NewHelperStore(name), nil
+	}
+}
+
+// resolveCredentials reads the secrets referenced by cfg from the credential
+// store into cfg. Secrets missing from the store are left empty, so that they
+// are prompted for or obtained again.
+func resolveCredentials(store credentials.Store, cfg *config.Config) error {
+	for _, slot := range credentialSlots(cfg) {
+		if *slot.ref == "" {
+			continue
+		}
+		if store == nil {
+			return fmt.Errorf("config references stored credentials %s, but no credentialHelper is configured", *slot.ref)
+		}
+		creds, err := store.Get(*slot.ref)
+		if err == credentials.ErrNotFound {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if err := slot.set(creds.Secret); err != nil {
+			return fmt.Errorf("could not read stored credentials %s: %v", *slot.ref, err)
+		}
+	}
+	return nil
+}
+
+// externalizeCredentials moves the secrets in cfg into the credential store,
+// leaving references behind, and erases stored secrets that were cleared. The
+// returned function puts the secrets back into cfg once it has been written.
+func externalizeCredentials(store credentials.Store, cfg *config.Config) (func(), error) {
+	restore := func() {}
+	if store == nil {
+		return restore, nil
+	}
+
+	for _, slot := range credentialSlots(cfg) {
+		secret, err := slot.get()
+		if err != nil {
+			return restore, err
+		}
+
+		if secret == "" {
+			if *slot.ref != "" {
+				if err := store.Erase(*slot.ref); err != nil && err != credentials.ErrNotFound {
+					return restore, err
+				}
+				*slot.ref = ""
+			}
+			continue
+		}
+
+		err = store.Store(&credentials.Credentials{
+			ServerURL: slot.serverURL,
+			Username:  slot.username,
+			Secret:    secret,
+		})
+		if err != nil {
+			return restore, err
+		}
+		*slot.ref = slot.serverURL
+
+		slot.set("")
+		prev, set := restore, slot.set
+		restore = func() {
+			set(secret)
+			prev()
+		}
+	}
+	return restore, nil
+}
+
+// credentialSlots lists the secrets of every context in cfg.
+func credentialSlots(cfg *config.Config) []credentialSlot {
+	var slots []credentialSlot
+	slots = append(slots, contextCredentialSlots("spin://config", &cfg.Context)...)
+	for _, name := range cfg.ContextNames() {
+		if ctx := cfg.Contexts[name]; ctx != nil {
+			slots = append(slots, contextCredentialSlots("spin://context/"+name, ctx)...)
+		}
+	}
+	return slots
+}
+
+func contextCredentialSlots(prefix string, ctx *config.Context) []credentialSlot {
+	auth := ctx.Auth
+	if auth == nil {
+		return nil
+	}
+
+	var slots []credentialSlot
+	if oauth := auth.OAuth2; oauth != nil {
+		slots = append(slots, tokenSlot(prefix+"/oauth2", &oauth.CachedToken, &oauth.CachedTokenRef))
+	}
+	if gsa := auth.GoogleServiceAccount; gsa != nil {
+		slots = append(slots, tokenSlot(prefix+"/google_service_account", &gsa.CachedToken, &gsa.CachedTokenRef))
+	}
+	if exec := auth.Exec; exec != nil {
+		slots = append(slots, tokenSlot(prefix+"/exec", &exec.CachedToken, &exec.CachedTokenRef))
+	}
+	if bearer := auth.Bearer; bearer != nil {
+		slots = append(slots, passwordSlot(prefix+"/bearer", "token", &bearer.Token, &bearer.TokenRef))
+	}
+	if basic := auth.Basic; basic != nil {
+		slots = append(slots, passwordSlot(prefix+"/basic", basic.Username, &basic.Password, &basic.PasswordRef))
+	}
+	if ldap := auth.Ldap; ldap != nil {
+		slots = append(slots, passwordSlot(prefix+"/ldap", ldap.Username, &ldap.Password, &ldap.PasswordRef))
+	}
+	return slots
+}
+
+func tokenSlot(serverURL string, token **oauth2.Token, ref *string) credentialSlot {
+	return credentialSlot{
+		serverURL: serverURL,
+		username:  "token",
+		ref:       ref,
+		get: func() (string, error) {
+			if *token == nil {
+				return "", nil
+			}
+			b, err := json.Marshal(*token)
+			return string(b), err
+		},
+		set: func(secret string) error {
+			if secret == "" {
+				*token = nil
+				return nil
+			}
+			t := &oauth2.Token{}
+			if err := json.Unmarshal([]byte(secret), t); err != nil {
+				return err
+			}
+			*token = t
+			return nil
+		},
+	}
+}
+
+func passwordSlot(serverURL, username string, password *string, ref *string) credentialSlot {
+	return credentialSlot{
+		serverURL: serverURL,
+		username:  username,
+		ref:       ref,
+		get: func() (string, error) {
+			return *password, nil
+		},
+		set: func(secret string) error {
+			*password = secret
+			return nil
+		},
+	}
+}
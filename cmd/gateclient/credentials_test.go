@@ -0,0 +1,155 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package gateclient
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spinnaker/spin/cmd/output"
+)
+
+func TestWriteConfig_credentialHelper(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spin-config")
+	if err != nil {
+		t.Fatalf("Could not create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	location := filepath.Join(dir, "config")
+	if err := ioutil.WriteFile(location, []byte(credentialHelperConfig), 0600); err != nil {
+		t.Fatalf("Could not write config file: %v", err)
+	}
+
+	ui := output.NewUI(true, false, output.MarshalToJson, nil, ioutil.Discard, ioutil.Discard)
+	cfg, err := LoadConfig(ui, location)
+	if err != nil {
+		t.Fatalf("Could not load config: %v", err)
+	}
+	if err := WriteConfig(cfg, location); err != nil {
+		t.Fatalf("Could not write config: %v", err)
+	}
+	if cfg.Auth.OAuth2.CachedToken.AccessToken != "access" || cfg.Contexts["prod"].Auth.Basic.Password != "pass" {
+		t.Fatalf("Secrets not restored after writing config")
+	}
+
+	written, err := ioutil.ReadFile(location)
+	if err != nil {
+		t.Fatalf("Could not read config file: %v", err)
+	}
+	for _, secret := range []string{"access", "refresh", "pass\n"} {
+		if strings.Contains(string(written), secret) {
+			t.Fatalf("Config file still contains %q:\n%s", secret, written)
+		}
+	}
+	for _, ref := range []string{"spin://config/oauth2", "spin://context/prod/basic"} {
+		if !strings.Contains(string(written), ref) {
+			t.Fatalf("Config file does not reference %q:\n%s", ref, written)
+		}
+	}
+
+	cfg, err = LoadConfig(ui, location)
+	if err != nil {
+		t.Fatalf("Could not reload config: %v", err)
+	}
+	if cfg.Auth.OAuth2.CachedToken == nil || cfg.Auth.OAuth2.CachedToken.RefreshToken != "refresh" {
+		t.Fatalf("Token not resolved from credential store: %+v", cfg.Auth.OAuth2.CachedToken)
+	}
+	if cfg.Contexts["prod"].Auth.Basic.Password != "pass" {
+		t.Fatalf("Password not resolved from credential store")
+	}
+
+	// Clearing the token erases it from the store.
+	cfg.Auth.OAuth2.CachedToken = nil
+	if err := WriteConfig(cfg, location); err != nil {
+		t.Fatalf("Could not write config: %v", err)
+	}
+	cfg, err = LoadConfig(ui, location)
+	if err != nil {
+		t.Fatalf("Could not reload config: %v", err)
+	}
+	if cfg.Auth.OAuth2.CachedToken != nil || cfg.Auth.OAuth2.CachedTokenRef != "" {
+		t.Fatalf("Token not erased from credential store")
+	}
+}
+
+func TestLoadConfig_missingCredentialHelper(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spin-config")
+	if err != nil {
+		t.Fatalf("Could not create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	location := filepath.Join(dir, "config")
+	config := strings.Replace(credentialHelperConfig, "credentialHelper: file", "", 1)
+	config = strings.Replace(config, "password: pass", "passwordRef: spin://context/prod/basic", 1)
+	if err := ioutil.WriteFile(location, []byte(config), 0600); err != nil {
+		t.Fatalf("Could not write config file: %v", err)
+	}
+
+	ui := output.NewUI(true, false, output.MarshalToJson, nil, ioutil.Discard, ioutil.Discard)
+	if _, err := LoadConfig(ui, location); err == nil {
+		t.Fatalf("Expected an error for references without a credential helper")
+	}
+}
+
+func TestLoadConfig_helperNotInstalled(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spin-config")
+	if err != nil {
+		t.Fatalf("Could not create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	location := filepath.Join(dir, "config")
+	config := strings.Replace(credentialHelperConfig, "credentialHelper: file", "credentialHelper: not-installed", 1)
+	if err := ioutil.WriteFile(location, []byte(config), 0600); err != nil {
+		t.Fatalf("Could not write config file: %v", err)
+	}
+
+	ui := output.NewUI(true, false, output.MarshalToJson, nil, ioutil.Discard, ioutil.Discard)
+	_, err = LoadConfig(ui, location)
+	if err == nil || !strings.Contains(err.Error(), "spin-credential-not-installed is not installed") {
+		t.Fatalf("Expected an error for a missing credential helper, got: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "credentials")); !os.IsNotExist(err) {
+		t.Fatalf("Expected no fallback to the file store")
+	}
+}
+
+const credentialHelperConfig = `
+credentialHelper: file
+gate:
+  endpoint: https://gate.example.com
+auth:
+  enabled: true
+  oauth2:
+    tokenUrl: https://example.com/token
+    authUrl: https://example.com/auth
+    clientId: spin
+    scopes:
+    - email
+    cachedToken:
+      access_token: access
+      refresh_token: refresh
+contexts:
+  prod:
+    gate:
+      endpoint: https://gate.prod.example.com
+    auth:
+      enabled: true
+      basic:
+        username: user
+        password: pass
+`
@@ -0,0 +1,76 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package gateclient
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/spinnaker/spin/config"
+	"sigs.k8s.io/yaml"
+)
+
+// currentContextLine matches the top-level currentContext key of a config
+// file, keeping any trailing comment in the second group.
+var currentContextLine = regexp.MustCompile(`(?m)^currentContext:([^#\n]*)((?:[ \t]#[^\n]*)?)$`)
+
+// SetCurrentContext sets the currentContext key of the spin config file at
+// location to name. Only that key is changed: the rest of the file, including
+// comments and unexpanded environment variables, is written back as it was.
+func SetCurrentContext(location, name string) error {
+	info, err := os.Stat(location)
+	if err != nil {
+		return err
+	}
+	raw, err := ioutil.ReadFile(location)
+	if err != nil {
+		return err
+	}
+
+	value, err := yaml.Marshal(name)
+	if err != nil {
+		return err
+	}
+	line := "currentContext: " + strings.TrimSpace(string(value))
+
+	var edited string
+	switch matches := currentContextLine.FindAllStringSubmatchIndex(string(raw), -1); len(matches) {
+	case 0:
+		edited = string(raw)
+		if edited != "" && !strings.HasSuffix(edited, "\n") {
+			edited += "\n"
+		}
+		edited += line + "\n"
+	case 1:
+		m := matches[0]
+		edited = string(raw[:m[0]]) + line + string(raw[m[4]:m[5]]) + string(raw[m[1]:])
+	default:
+		return fmt.Errorf("currentContext is set more than once in %s", location)
+	}
+
+	// Make sure the edit did what was intended before replacing the file.
+	cfg := &config.Config{}
+	if err := yaml.Unmarshal([]byte(os.ExpandEnv(edited)), cfg); err != nil {
+		return err
+	}
+	if cfg.CurrentContext != name {
+		return fmt.Errorf("could not set currentContext in %s", location)
+	}
+
+	return ioutil.WriteFile(location, []byte(edited), info.Mode())
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package gateclient
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestSetCurrentContext(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   string
+		context  string
+		expected string
+	}{
+		{
+			name:     "replaced",
+			config:   "currentContext: dev\ncontexts: {}\n",
+			context:  "prod",
+			expected: "currentContext: prod\ncontexts: {}\n",
+		},
+		{
+			name:     "comment kept",
+			config:   "# spin config\ncurrentContext: dev # the usual one\ncontexts: {}\n",
+			context:  "prod",
+			expected: "# spin config\ncurrentContext: prod # the usual one\ncontexts: {}\n",
+		},
+		{
+			name:     "added",
+			config:   "gate:\n  endpoint: ${GATE_URL}",
+			context:  "prod",
+			expected: "gate:\n  endpoint: ${GATE_URL}\ncurrentContext: prod\n",
+		},
+		{
+			name:     "nested keys untouched",
+			config:   "contexts:\n  dev:\n    currentContext: x\ncurrentContext: dev\n",
+			context:  "prod",
+			expected: "contexts:\n  dev:\n    currentContext: x\ncurrentContext: prod\n",
+		},
+		{
+			name:     "quoted",
+			config:   "currentContext: dev\n",
+			context:  "yes",
+			expected: "currentContext: \"yes\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ioutil.TempFile("", "spin-config")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.Remove(f.Name())
+			if _, err := f.WriteString(tt.config); err != nil {
+				t.Fatal(err)
+			}
+			f.Close()
+
+			if err := SetCurrentContext(f.Name(), tt.context); err != nil {
+				t.Fatalf("SetCurrentContext failed: %v", err)
+			}
+			written, err := ioutil.ReadFile(f.Name())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(written) != tt.expected {
+				t.Fatalf("Unexpected config file:\n%s", written)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package gateclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// maxPlainErrorLen is the longest non-JSON error body shown to users.
+const maxPlainErrorLen = 500
+
+// GateError is an error response from Gate, with the details decoded from
+// Gate's and Spring's JSON error bodies.
+type GateError struct {
+	StatusCode int
+	Message    string
+	Errors     []string
+	Exception  string
+}
+
+func (e *GateError) Error() string {
+	msg := fmt.Sprintf("status code: %d", e.StatusCode)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.Exception != "" {
+		msg += " (" + e.Exception + ")"
+	}
+	for _, err := range e.Errors {
+		msg += "\n  - " + err
+	}
+	return msg
+}
+
+// swaggerError is implemented by errors of newer swagger-codegen clients,
+// which keep the response body.
+type swaggerError interface {
+	Body() []byte
+}
+
+// errorBody is the union of Gate's and Spring's error responses.
+type errorBody struct {
+	Message   string        `json:"message"`
+	Error     string        `json:"error"`
+	Errors    []interface{} `json:"errors"`
+	Exception string        `json:"exception"`
+}
+
+// ResponseError returns the error to report for a failed Gate call. If Gate
+// responded, it is a *GateError with the details from the response body,
+// which the generated client only keeps in err. Otherwise err is returned.
+// It must only be called for failed calls, i.e. with a non-nil err or an
+// unexpected status code.
+func ResponseError(resp *http.Response, err error) error {
+	// Without an error status, err is not about Gate's response, e.g. a
+	// response that failed to decode.
+	if resp == nil || (err != nil && resp.StatusCode < http.StatusMultipleChoices) {
+		return err
+	}
+
+	gateErr := &GateError{StatusCode: resp.StatusCode}
+	body := strings.TrimSpace(string(responseBody(resp, err)))
+	if body == "" {
+		return gateErr
+	}
+
+	var decoded errorBody
+	if json.Unmarshal([]byte(body), &decoded) != nil {
+		if !strings.HasPrefix(body, "<") && len(body) <= maxPlainErrorLen {
+			gateErr.Message = body
+		}
+		return gateErr
+	}
+
+	gateErr.Message = decoded.Message
+	if gateErr.Message == "" {
+		gateErr.Message = decoded.Error
+	}
+	gateErr.Exception = decoded.Exception
+	for _, e := range decoded.Errors {
+		if m, ok := e.(map[string]interface{}); ok && m["message"] != nil {
+			e = m["message"]
+		}
+		gateErr.Errors = append(gateErr.Errors, fmt.Sprintf("%v", e))
+	}
+	return gateErr
+}
+
+// responseBody extracts the response body from an error of the generated
+// client, which formats it as "Status: <status>, Body: <body>".
+func responseBody(resp *http.Response, err error) []byte {
+	if err == nil {
+		return nil
+	}
+	if swaggerErr, ok := err.(swaggerError); ok {
+		return swaggerErr.Body()
+	}
+	prefix := fmt.Sprintf("Status: %s, Body: ", resp.Status)
+	if msg := err.Error(); strings.HasPrefix(msg, prefix) {
+		return []byte(strings.TrimPrefix(msg, prefix))
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package gateclient
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestResponseError_spring(t *testing.T) {
+	resp, err := testErrorResponse(http.StatusBadRequest, `{"timestamp": 1, "status": 400, "error": "Bad Request", "message": "Pipeline name is required", "exception": "com.netflix.spinnaker.kork.web.exceptions.ValidationException"}`)
+	gateErr, ok := ResponseError(resp, err).(*GateError)
+	if !ok {
+		t.Fatalf("Expected a GateError, got: %v", ResponseError(resp, err))
+	}
+	if gateErr.StatusCode != http.StatusBadRequest || gateErr.Message != "Pipeline name is required" {
+		t.Fatalf("Unexpected error: %+v", gateErr)
+	}
+	expected := "status code: 400: Pipeline name is required (com.netflix.spinnaker.kork.web.exceptions.ValidationException)"
+	if gateErr.Error() != expected {
+		t.Fatalf("Expected %q, got %q", expected, gateErr.Error())
+	}
+}
+
+func TestResponseError_errors(t *testing.T) {
+	resp, err := testErrorResponse(http.StatusBadRequest, `{"error": "Bad Request", "errors": ["stages is required", {"message": "triggers must be a list"}]}`)
+	expected := "status code: 400: Bad Request\n  - stages is required\n  - triggers must be a list"
+	if msg := ResponseError(resp, err).Error(); msg != expected {
+		t.Fatalf("Expected %q, got %q", expected, msg)
+	}
+}
+
+func TestResponseError_plainText(t *testing.T) {
+	resp, err := testErrorResponse(http.StatusServiceUnavailable, "upstream connect error\n")
+	expected := "status code: 503: upstream connect error"
+	if msg := ResponseError(resp, err).Error(); msg != expected {
+		t.Fatalf("Expected %q, got %q", expected, msg)
+	}
+}
+
+func TestResponseError_html(t *testing.T) {
+	resp, err := testErrorResponse(http.StatusBadGateway, "<html><body>Bad Gateway</body></html>")
+	expected := "status code: 502"
+	if msg := ResponseError(resp, err).Error(); msg != expected {
+		t.Fatalf("Expected %q, got %q", expected, msg)
+	}
+}
+
+func TestResponseError_emptyBody(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusNotFound, Status: "404 Not Found"}
+	expected := "status code: 404"
+	if msg := ResponseError(resp, nil).Error(); msg != expected {
+		t.Fatalf("Expected %q, got %q", expected, msg)
+	}
+}
+
+func TestResponseError_passthrough(t *testing.T) {
+	connErr := errors.New("connection refused")
+	if err := ResponseError(nil, connErr); err != connErr {
+		t.Fatalf("Expected the error without a response to be returned, got: %v", err)
+	}
+
+	decodeErr := errors.New("invalid character 'x' looking for beginning of value")
+	resp := &http.Response{StatusCode: http.StatusOK, Status: "200 OK"}
+	if err := ResponseError(resp, decodeErr); err != decodeErr {
+		t.Fatalf("Expected the error of a successful response to be returned, got: %v", err)
+	}
+}
+
+// testErrorResponse returns a response with the status and the error the
+// generated client returns for it.
+func testErrorResponse(status int, body string) (*http.Response, error) {
+	resp := &http.Response{
+		StatusCode: status,
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+	}
+	return resp, fmt.Errorf("Status: %v, Body: %s", resp.Status, body)
+}
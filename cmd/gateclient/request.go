@@ -0,0 +1,89 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package gateclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	gate "github.com/spinnaker/spin/gateapi"
+)
+
+// NewRequest returns a request to path on Gate with the headers and
+// authentication the generated API client sends. If body is not nil, it is
+// sent as JSON.
+//
+// It is meant for the few endpoints whose responses the generated client
+// discards, such as the reference to a triggered pipeline execution.
+func (m *GatewayClient) NewRequest(method, path string, body interface{}) (*http.Request, error) {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, m.apiConfig.BasePath+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(m.Context)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", m.apiConfig.UserAgent)
+
+	if auth, ok := m.Context.Value(gate.ContextBasicAuth).(gate.BasicAuth); ok {
+		req.SetBasicAuth(auth.UserName, auth.Password)
+	}
+	if token, ok := m.Context.Value(gate.ContextAccessToken).(string); ok {
+		req.Header.Add("Authorization", "Bearer "+token)
+	}
+	for header, value := range m.apiConfig.DefaultHeader {
+		req.Header.Add(header, value)
+	}
+	return req, nil
+}
+
+// Do sends the request with the client used for all Gate requests, so it is
+// traced and retried like them, and decodes the JSON response body into
+// result unless it is nil. Error statuses are reported like the generated
+// client does, so that ResponseError can describe them.
+func (m *GatewayClient) Do(req *http.Request, result interface{}) (*http.Response, error) {
+	resp, err := m.apiConfig.HTTPClient.Do(req)
+	if err != nil {
+		return resp, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return resp, err
+	}
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return resp, fmt.Errorf("Status: %v, Body: %s", resp.Status, body)
+	}
+	if result == nil || len(bytes.TrimSpace(body)) == 0 {
+		return resp, nil
+	}
+	return resp, json.Unmarshal(body, result)
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package gateclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gate "github.com/spinnaker/spin/gateapi"
+)
+
+func TestRequest(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ := r.BasicAuth()
+		switch {
+		case user != "user" || pass != "pass":
+			http.Error(w, `{"message": "Unauthorized"}`, http.StatusUnauthorized)
+		case r.Header.Get("X-Team") != "deploy" || r.Header.Get("User-Agent") != "spin/test":
+			http.Error(w, `{"message": "Missing headers"}`, http.StatusBadRequest)
+		case r.Header.Get("Content-Type") != "application/json":
+			http.Error(w, `{"message": "Unsupported media type"}`, http.StatusUnsupportedMediaType)
+		default:
+			w.WriteHeader(http.StatusAccepted)
+			fmt.Fprintln(w, `{"ref": "/pipelines/exec1"}`)
+		}
+	}))
+	defer ts.Close()
+
+	m := testRequestClient(ts, gate.BasicAuth{UserName: "user", Password: "pass"})
+	req, err := m.NewRequest(http.MethodPost, "/pipelines/app/one", map[string]interface{}{"type": "manual"})
+	if err != nil {
+		t.Fatalf("NewRequest failed: %v", err)
+	}
+	var accepted struct {
+		Ref string `json:"ref"`
+	}
+	resp, err := m.Do(req, &accepted)
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusAccepted || accepted.Ref != "/pipelines/exec1" {
+		t.Fatalf("Unexpected response: %d %+v", resp.StatusCode, accepted)
+	}
+
+	m = testRequestClient(ts, gate.BasicAuth{UserName: "user", Password: "wrong"})
+	req, err = m.NewRequest(http.MethodPost, "/pipelines/app/one", nil)
+	if err != nil {
+		t.Fatalf("NewRequest failed: %v", err)
+	}
+	resp, err = m.Do(req, nil)
+	if err == nil {
+		t.Fatalf("Expected error for unauthorized request")
+	}
+	if reported := ResponseError(resp, err); !strings.Contains(reported.Error(), "status code: 401: Unauthorized") {
+		t.Fatalf("Unexpected error: %v", reported)
+	}
+}
+
+func testRequestClient(ts *httptest.Server, auth gate.BasicAuth) *GatewayClient {
+	return &GatewayClient{
+		Context: context.WithValue(context.Background(), gate.ContextBasicAuth, auth),
+		apiConfig: &gate.Configuration{
+			BasePath:      ts.URL,
+			DefaultHeader: map[string]string{"X-Team": "deploy"},
+			UserAgent:     "spin/test",
+			HTTPClient:    ts.Client(),
+		},
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package gateclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/spinnaker/spin/cmd/output"
+	"github.com/spinnaker/spin/config"
+	"github.com/spinnaker/spin/util"
+)
+
+const (
+	defaultRetryMaxAttempts    = 3
+	defaultRetryInitialBackoff = 500 * time.Millisecond
+	defaultRetryMaxBackoff     = 10 * time.Second
+)
+
+// retryableStatus are the response codes of transient Gate failures, such as
+// Gate pods rolling behind a load balancer.
+var retryableStatus = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+// idempotentMethods are retried by default. Other methods, notably POST, are
+// only retried if explicitly enabled since Gate may have acted on a request
+// whose response was lost.
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+}
+
+// retryTransport is an http.RoundTripper retrying transient failures with
+// exponential backoff and jitter, honoring Retry-After.
+type retryTransport struct {
+	// base is the transport doing the requests, http.DefaultTransport if nil.
+	base http.RoundTripper
+
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	retryPost      bool
+
+	ui    output.Ui
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// newRetryTransport creates a retrying transport for base. The flag values
+// take precedence over the config's retry settings when set.
+func newRetryTransport(base http.RoundTripper, ui output.Ui, cfg *config.Retry, maxAttempts int, retryPost bool) (*retryTransport, error) {
+	t := &retryTransport{
+		base:           base,
+		maxAttempts:    defaultRetryMaxAttempts,
+		initialBackoff: defaultRetryInitialBackoff,
+		maxBackoff:     defaultRetryMaxBackoff,
+		ui:             ui,
+		sleep:          util.Sleep,
+	}
+
+	if cfg != nil {
+		if cfg.MaxAttempts != 0 {
+			t.maxAttempts = cfg.MaxAttempts
+		}
+		if cfg.InitialBackoff != "" {
+			d, err := time.ParseDuration(cfg.InitialBackoff)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid retry initialBackoff %q: %v", cfg.InitialBackoff, err)
+			}
+			t.initialBackoff = d
+		}
+		if cfg.MaxBackoff != "" {
+			d, err := time.ParseDuration(cfg.MaxBackoff)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid retry maxBackoff %q: %v", cfg.MaxBackoff, err)
+			}
+			t.maxBackoff = d
+		}
+		t.retryPost = cfg.RetryPost
+	}
+
+	if maxAttempts != 0 {
+		t.maxAttempts = maxAttempts
+	}
+	if retryPost {
+		t.retryPost = true
+	}
+	if t.maxAttempts < 1 {
+		return nil, errors.New("Retry max attempts must be at least 1")
+	}
+	return t, nil
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	r := req
+	for attempt := 1; ; attempt++ {
+		resp, err := base.RoundTrip(r)
+		if attempt >= t.maxAttempts || !t.retryable(req, resp, err) {
+			return resp, err
+		}
+
+		delay := t.backoff(attempt)
+		var reason string
+		if err != nil {
+			reason = err.Error()
+		} else {
+			reason = resp.Status
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+				if retryAfter > t.maxBackoff {
+					// Gate asked for more patience than we are willing to give.
+					return resp, nil
+				}
+				delay = retryAfter
+			}
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		// The request must not be modified, so the body is replayed on a copy.
+		if req.Body != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			r = req.WithContext(req.Context())
+			r.Body = body
+		}
+
+		t.ui.Warn(fmt.Sprintf("%s %s failed: %s, retrying in %s (attempt %d of %d)",
+			req.Method, req.URL.Path, reason, delay.Round(time.Millisecond), attempt+1, t.maxAttempts))
+		if err := t.sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// retryable reports whether the outcome of req is a transient failure that
+// may safely be retried.
+func (t *retryTransport) retryable(req *http.Request, resp *http.Response, err error) bool {
+	if !idempotentMethods[req.Method] && !(t.retryPost && req.Method == http.MethodPost) {
+		return false
+	}
+	// Requests with a body can only be retried if it can be replayed.
+	if req.Body != nil && req.GetBody == nil {
+		return false
+	}
+	if err != nil {
+		return req.Context().Err() == nil
+	}
+	return retryableStatus[resp.StatusCode]
+}
+
+// backoff returns the delay before the given retry: exponential in the
+// number of attempts, capped at maxBackoff, with the upper half jittered.
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	d := t.initialBackoff
+	for i := 1; i < attempt && d < t.maxBackoff; i++ {
+		d *= 2
+	}
+	if d > t.maxBackoff {
+		d = t.maxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an
+// HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		d := time.Until(date)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
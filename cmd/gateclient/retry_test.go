@@ -0,0 +1,232 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package gateclient
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spinnaker/spin/cmd/output"
+	"github.com/spinnaker/spin/config"
+	"sigs.k8s.io/yaml"
+)
+
+func TestRetryTransport_transient(t *testing.T) {
+	ts, requests := testFlakyServer(2, http.StatusServiceUnavailable, "")
+	defer ts.Close()
+
+	transport, delays := testRetryTransport(t, nil, 0, false)
+	resp, err := (&http.Client{Transport: transport}).Get(ts.URL)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || len(*requests) != 3 || len(*delays) != 2 {
+		t.Fatalf("Unexpected status %d after %d requests", resp.StatusCode, len(*requests))
+	}
+	// Backoff doubles, jittered within the upper half.
+	if (*delays)[0] < 250*time.Millisecond || (*delays)[0] > 500*time.Millisecond ||
+		(*delays)[1] < 500*time.Millisecond || (*delays)[1] > time.Second {
+		t.Fatalf("Unexpected backoff: %v", *delays)
+	}
+}
+
+func TestRetryTransport_maxAttempts(t *testing.T) {
+	ts, requests := testFlakyServer(5, http.StatusBadGateway, "")
+	defer ts.Close()
+
+	transport, _ := testRetryTransport(t, &config.Retry{MaxAttempts: 4}, 2, false)
+	resp, err := (&http.Client{Transport: transport}).Get(ts.URL)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	// The flag takes precedence over the config.
+	if resp.StatusCode != http.StatusBadGateway || len(*requests) != 2 {
+		t.Fatalf("Unexpected status %d after %d requests", resp.StatusCode, len(*requests))
+	}
+}
+
+func TestRetryTransport_notRetryable(t *testing.T) {
+	ts, requests := testFlakyServer(1, http.StatusInternalServerError, "")
+	defer ts.Close()
+
+	transport, _ := testRetryTransport(t, nil, 0, false)
+	resp, err := (&http.Client{Transport: transport}).Get(ts.URL)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusInternalServerError || len(*requests) != 1 {
+		t.Fatalf("Unexpected status %d after %d requests", resp.StatusCode, len(*requests))
+	}
+}
+
+func TestRetryTransport_post(t *testing.T) {
+	ts, requests := testFlakyServer(1, http.StatusServiceUnavailable, "")
+	defer ts.Close()
+
+	transport, _ := testRetryTransport(t, nil, 0, false)
+	resp, err := (&http.Client{Transport: transport}).Post(ts.URL, "application/json", strings.NewReader(`{"a": 1}`))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable || len(*requests) != 1 {
+		t.Fatalf("POST retried without opt-in: status %d after %d requests", resp.StatusCode, len(*requests))
+	}
+
+	ts, requests = testFlakyServer(1, http.StatusServiceUnavailable, "")
+	defer ts.Close()
+
+	transport, _ = testRetryTransport(t, nil, 0, true)
+	resp, err = (&http.Client{Transport: transport}).Post(ts.URL, "application/json", strings.NewReader(`{"a": 1}`))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || len(*requests) != 2 {
+		t.Fatalf("Unexpected status %d after %d requests", resp.StatusCode, len(*requests))
+	}
+	for _, body := range *requests {
+		if body != `{"a": 1}` {
+			t.Fatalf("Request body not replayed: %q", body)
+		}
+	}
+}
+
+func TestRetryTransport_retryAfter(t *testing.T) {
+	ts, requests := testFlakyServer(1, http.StatusTooManyRequests, "2")
+	defer ts.Close()
+
+	transport, delays := testRetryTransport(t, nil, 0, false)
+	resp, err := (&http.Client{Transport: transport}).Get(ts.URL)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || len(*requests) != 2 || (*delays)[0] != 2*time.Second {
+		t.Fatalf("Unexpected status %d after %d requests, delays %v", resp.StatusCode, len(*requests), *delays)
+	}
+
+	// Longer waits than the maximum backoff are not retried.
+	ts, requests = testFlakyServer(1, http.StatusServiceUnavailable, "3600")
+	defer ts.Close()
+
+	transport, _ = testRetryTransport(t, nil, 0, false)
+	resp, err = (&http.Client{Transport: transport}).Get(ts.URL)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable || len(*requests) != 1 {
+		t.Fatalf("Unexpected status %d after %d requests", resp.StatusCode, len(*requests))
+	}
+}
+
+func TestRetryTransport_connectionError(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	ts.Close()
+
+	transport, delays := testRetryTransport(t, nil, 0, false)
+	_, err := (&http.Client{Transport: transport}).Get(ts.URL)
+	if err == nil {
+		t.Fatalf("Expected connection error")
+	}
+	if len(*delays) != 2 {
+		t.Fatalf("Expected 2 retries, got %d", len(*delays))
+	}
+}
+
+func TestResolveRetry(t *testing.T) {
+	example, err := ioutil.ReadFile("../../config/example.yaml")
+	if err != nil {
+		t.Fatalf("Could not read example config: %v", err)
+	}
+	var cfg config.Config
+	if err := yaml.UnmarshalStrict(example, &cfg); err != nil {
+		t.Fatalf("Could not parse example config: %v", err)
+	}
+
+	// The current context sets no retry block, so the top-level one applies.
+	ctx, err := cfg.ResolveContext("")
+	if err != nil {
+		t.Fatalf("Could not resolve context: %v", err)
+	}
+	if retry := cfg.ResolveRetry(ctx); retry == nil || retry.MaxAttempts != 3 {
+		t.Fatalf("Top-level retry not used for context %q: %+v", cfg.CurrentContext, retry)
+	}
+
+	// A context's own retry block takes precedence.
+	ctx.Retry = &config.Retry{MaxAttempts: 5}
+	if retry := cfg.ResolveRetry(ctx); retry.MaxAttempts != 5 {
+		t.Fatalf("Context retry not used: %+v", retry)
+	}
+}
+
+func TestNewRetryTransport_invalid(t *testing.T) {
+	ui := output.NewUI(true, false, output.MarshalToJson, nil, ioutil.Discard, ioutil.Discard)
+	for _, cfg := range []*config.Retry{{InitialBackoff: "soon"}, {MaxBackoff: "10"}, {MaxAttempts: -1}} {
+		if _, err := newRetryTransport(nil, ui, cfg, 0, false); err == nil {
+			t.Errorf("Expected an error for %+v", cfg)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if d, ok := parseRetryAfter("5"); !ok || d != 5*time.Second {
+		t.Fatalf("Unexpected delay for seconds: %v", d)
+	}
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	if d, ok := parseRetryAfter(date); !ok || d <= 0 || d > time.Minute {
+		t.Fatalf("Unexpected delay for date: %v", d)
+	}
+	if _, ok := parseRetryAfter("later"); ok {
+		t.Fatalf("Expected invalid Retry-After to be ignored")
+	}
+}
+
+// testRetryTransport returns a retry transport recording its delays instead
+// of sleeping.
+func testRetryTransport(t *testing.T, cfg *config.Retry, maxAttempts int, retryPost bool) (*retryTransport, *[]time.Duration) {
+	ui := output.NewUI(true, false, output.MarshalToJson, nil, ioutil.Discard, ioutil.Discard)
+	transport, err := newRetryTransport(nil, ui, cfg, maxAttempts, retryPost)
+	if err != nil {
+		t.Fatalf("Could not create retry transport: %v", err)
+	}
+	delays := &[]time.Duration{}
+	transport.sleep = func(ctx context.Context, d time.Duration) error {
+		*delays = append(*delays, d)
+		return nil
+	}
+	return transport, delays
+}
+
+// testFlakyServer fails the first failures requests with status, then
+// succeeds. The bodies of all requests are recorded.
+func testFlakyServer(failures, status int, retryAfter string) (*httptest.Server, *[]string) {
+	requests := &[]string{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		*requests = append(*requests, string(body))
+		if len(*requests) <= failures {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	return ts, requests
+}
@@ -0,0 +1,198 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package gateclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spinnaker/spin/cmd/output"
+)
+
+// Verbosity levels of the --verbose flag.
+const (
+	// traceRequests logs the method, URL, status and latency of requests.
+	traceRequests = 1
+	// traceBodies additionally logs request and response headers and bodies.
+	traceBodies = 2
+)
+
+const redacted = "REDACTED"
+
+// sensitiveHeaders are never logged.
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+}
+
+// sensitiveFields are substrings of JSON keys and form fields whose values are
+// never logged.
+var sensitiveFields = []string{"password", "secret", "token"}
+
+// traceTransport is an http.RoundTripper logging requests and responses
+// through the Ui, redacting credentials.
+type traceTransport struct {
+	// base is the transport doing the requests, http.DefaultTransport if nil.
+	base  http.RoundTripper
+	level int
+	ui    output.Ui
+}
+
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	if t.level >= traceBodies {
+		t.ui.Debug(t.dumpRequest(req))
+	}
+
+	start := time.Now()
+	resp, err := base.RoundTrip(req)
+	latency := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		t.ui.Debug(fmt.Sprintf("%s %s failed after %s: %v", req.Method, req.URL, latency, err))
+		return resp, err
+	}
+
+	if t.level >= traceBodies {
+		dump, err := t.dumpResponse(resp, latency)
+		if err != nil {
+			return nil, err
+		}
+		t.ui.Debug(dump)
+	} else {
+		t.ui.Debug(fmt.Sprintf("%s %s %s %s", req.Method, req.URL, resp.Status, latency))
+	}
+	return resp, nil
+}
+
+func (t *traceTransport) dumpRequest(req *http.Request) string {
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "> %s %s\n", req.Method, req.URL)
+	writeHeaders(buf, "> ", req.Header)
+
+	// The body is read from a copy so that the request is left untouched.
+	if req.Body != nil && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			b, _ := ioutil.ReadAll(body)
+			body.Close()
+			writeBody(buf, "> ", req.Header.Get("Content-Type"), b)
+		}
+	}
+	return strings.TrimRight(buf.String(), "\n")
+}
+
+func (t *traceTransport) dumpResponse(resp *http.Response, latency time.Duration) (string, error) {
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "< %s (%s)\n", resp.Status, latency)
+	writeHeaders(buf, "< ", resp.Header)
+
+	b, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return "", err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(b))
+	writeBody(buf, "< ", resp.Header.Get("Content-Type"), b)
+	return strings.TrimRight(buf.String(), "\n"), nil
+}
+
+func writeHeaders(buf *bytes.Buffer, prefix string, header http.Header) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := strings.Join(header[name], ", ")
+		if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+			value = redacted
+		}
+		fmt.Fprintf(buf, "%s%s: %s\n", prefix, name, value)
+	}
+}
+
+func writeBody(buf *bytes.Buffer, prefix, contentType string, body []byte) {
+	if len(body) == 0 {
+		return
+	}
+	fmt.Fprintln(buf, prefix)
+	for _, line := range strings.Split(redactBody(contentType, body), "\n") {
+		fmt.Fprintf(buf, "%s%s\n", prefix, line)
+	}
+}
+
+// redactBody returns the body with the values of sensitive JSON keys and
+// form fields replaced.
+func redactBody(contentType string, body []byte) string {
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		form, err := url.ParseQuery(string(body))
+		if err == nil {
+			for key := range form {
+				if sensitiveField(key) {
+					form[key] = []string{redacted}
+				}
+			}
+			return form.Encode()
+		}
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err == nil {
+		if b, err := json.MarshalIndent(redactJson(data), "", " "); err == nil {
+			return string(b)
+		}
+	}
+	return strings.TrimRight(string(body), "\n")
+}
+
+func redactJson(data interface{}) interface{} {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if sensitiveField(key) {
+				v[key] = redacted
+			} else {
+				v[key] = redactJson(value)
+			}
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redactJson(value)
+		}
+	}
+	return data
+}
+
+func sensitiveField(name string) bool {
+	name = strings.ToLower(name)
+	for _, field := range sensitiveFields {
+		if strings.Contains(name, field) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package gateclient
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/spinnaker/spin/cmd/output"
+)
+
+func TestTraceTransport_requests(t *testing.T) {
+	ts := testTraceServer()
+	defer ts.Close()
+
+	client, stderr := testTraceClient(traceRequests)
+	resp, err := client.Get(ts.URL + "/applications")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	expected := regexp.MustCompile(`^GET http://127.0.0.1:\d+/applications 200 OK \S+\n$`)
+	if !expected.MatchString(stderr.String()) {
+		t.Fatalf("Unexpected trace:\n%s", stderr)
+	}
+}
+
+func TestTraceTransport_bodies(t *testing.T) {
+	ts := testTraceServer()
+	defer ts.Close()
+
+	client, stderr := testTraceClient(traceBodies)
+	req, _ := http.NewRequest("POST", ts.URL+"/pipelines", strings.NewReader(`{"name": "deploy", "trigger": {"password": "hunter2"}}`))
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if strings.TrimSpace(string(body)) != `{"name": "deploy", "accessToken": "abc"}` {
+		t.Fatalf("Response body not preserved: %s", body)
+	}
+
+	trace := stderr.String()
+	for _, secret := range []string{"secret", "hunter2", "abc", "SESSION=1"} {
+		if strings.Contains(trace, secret) {
+			t.Fatalf("Trace contains %q:\n%s", secret, trace)
+		}
+	}
+	for _, expected := range []string{
+		"> POST " + ts.URL + "/pipelines",
+		"> Authorization: REDACTED",
+		`>   "password": "REDACTED"`,
+		"< 200 OK (",
+		"< Set-Cookie: REDACTED",
+		`<  "accessToken": "REDACTED",`,
+		`<  "name": "deploy"`,
+	} {
+		if !strings.Contains(trace, expected) {
+			t.Fatalf("Trace does not contain %q:\n%s", expected, trace)
+		}
+	}
+}
+
+func TestTraceTransport_form(t *testing.T) {
+	ts := testTraceServer()
+	defer ts.Close()
+
+	client, stderr := testTraceClient(traceBodies)
+	resp, err := client.PostForm(ts.URL+"/login", url.Values{"username": {"user"}, "password": {"hunter2"}})
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if !strings.Contains(stderr.String(), "> password=REDACTED&username=user") {
+		t.Fatalf("Form not redacted:\n%s", stderr)
+	}
+}
+
+func TestTraceTransport_error(t *testing.T) {
+	ts := testTraceServer()
+	ts.Close()
+
+	client, stderr := testTraceClient(traceRequests)
+	if _, err := client.Get(ts.URL); err == nil {
+		t.Fatalf("Expected connection error")
+	}
+	if !strings.Contains(stderr.String(), "GET "+ts.URL+" failed after") {
+		t.Fatalf("Unexpected trace:\n%s", stderr)
+	}
+}
+
+// testTraceClient returns an http client tracing at the given level to the
+// returned buffer.
+func testTraceClient(level int) (*http.Client, *bytes.Buffer) {
+	stderr := new(bytes.Buffer)
+	ui := output.NewUI(true, false, output.MarshalToJson, nil, ioutil.Discard, stderr)
+	return &http.Client{Transport: &traceTransport{level: level, ui: ui}}, stderr
+}
+
+func testTraceServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "SESSION", Value: "1"})
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, `{"name": "deploy", "accessToken": "abc"}`)
+	}))
+}
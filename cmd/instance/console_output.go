@@ -0,0 +1,117 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package instance
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+	"github.com/spinnaker/spin/util"
+)
+
+type consoleOutputOptions struct {
+	*instanceOptions
+	account  string
+	region   string
+	provider string
+}
+
+var (
+	consoleOutputInstanceShort   = "Print the console output of the specified instance"
+	consoleOutputInstanceLong    = "Print the console output, such as the boot log, of the instance with the provided id"
+	consoleOutputInstanceExample = "usage: spin instance console-output [options] instance-id"
+)
+
+func NewConsoleOutputCmd(instanceOptions *instanceOptions) *cobra.Command {
+	options := &consoleOutputOptions{
+		instanceOptions: instanceOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "console-output",
+		Aliases: []string{"console"},
+		Short:   consoleOutputInstanceShort,
+		Long:    consoleOutputInstanceLong,
+		Example: consoleOutputInstanceExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return consoleOutput(cmd, options, args)
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&options.account, "account", "", "account the instance runs in")
+	cmd.PersistentFlags().StringVar(&options.region, "region", "", "region, or namespace for Kubernetes, of the instance")
+	cmd.PersistentFlags().StringVar(&options.provider, "provider", "aws", "cloud provider of the instance")
+
+	return cmd
+}
+
+func consoleOutput(cmd *cobra.Command, options *consoleOutputOptions, args []string) error {
+	id, err := util.ReadArgsOrStdin(args)
+	if err != nil {
+		return err
+	}
+	if options.account == "" || options.region == "" {
+		return errors.New("one of required parameters 'account' or 'region' not set")
+	}
+
+	console, resp, err := options.GateClient.InstanceControllerApi.GetConsoleOutputUsingGET(options.GateClient.Context,
+		options.account,
+		id,
+		options.region,
+		map[string]interface{}{"provider": options.provider})
+	if resp != nil {
+		if resp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("Instance '%s' not found in %s/%s\n", id, options.account, options.region)
+		} else if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("Encountered an error getting console output of instance %s, %v\n", id, gateclient.ResponseError(resp, err))
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	var text string
+	if c, ok := console.(map[string]interface{}); ok {
+		text = consoleText(c["output"])
+	}
+	options.Ui.Output(text)
+	return nil
+}
+
+// consoleText returns the console output as text. Most providers return it
+// as a string, some as a list of named outputs, e.g. one per container.
+func consoleText(output interface{}) string {
+	switch o := output.(type) {
+	case string:
+		return o
+	case []interface{}:
+		var b strings.Builder
+		for _, item := range o {
+			named, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			fmt.Fprintf(&b, "==> %v <==\n%v\n", named["name"], strings.TrimRight(fmt.Sprintf("%v", named["output"]), "\n"))
+		}
+		return strings.TrimRight(b.String(), "\n")
+	default:
+		return ""
+	}
+}
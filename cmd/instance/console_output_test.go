@@ -0,0 +1,101 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package instance
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spinnaker/spin/cmd"
+	"github.com/spinnaker/spin/util"
+)
+
+func TestInstanceConsoleOutput_text(t *testing.T) {
+	ts := testGateConsoleOutputSuccess(`{"output": "[    0.000000] Linux version 5.4.0\ncloud-init: failed to mount /data\n"}`)
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, options := cmd.NewCmdRoot(buffer, ioutil.Discard)
+	rootCmd.AddCommand(NewInstanceCmd(options))
+
+	args := []string{"instance", "console-output", "--account", "prod", "--region", "us-east-1", "i-0123", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	expected := "[    0.000000] Linux version 5.4.0\ncloud-init: failed to mount /data"
+	recieved := strings.TrimSpace(buffer.String())
+	if expected != recieved {
+		t.Fatalf("Unexpected command output:\n%s", recieved)
+	}
+}
+
+func TestInstanceConsoleOutput_named(t *testing.T) {
+	ts := testGateConsoleOutputSuccess(`{"output": [{"name": "app", "output": "started\n"}, {"name": "sidecar", "output": "ready"}]}`)
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, options := cmd.NewCmdRoot(buffer, ioutil.Discard)
+	rootCmd.AddCommand(NewInstanceCmd(options))
+
+	args := []string{"instance", "console", "--account", "prod", "--region", "us-east-1", "i-0123", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	expected := "==> app <==\nstarted\n==> sidecar <==\nready"
+	recieved := strings.TrimSpace(buffer.String())
+	if expected != recieved {
+		t.Fatalf("Unexpected command output:\n%s", recieved)
+	}
+}
+
+func TestInstanceConsoleOutput_fail(t *testing.T) {
+	ts := testGateFail()
+	defer ts.Close()
+
+	rootCmd, options := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewInstanceCmd(options))
+
+	args := []string{"instance", "console-output", "--account", "prod", "--region", "us-east-1", "i-0123", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Expected failure but command succeeded")
+	}
+}
+
+// testGateConsoleOutputSuccess spins up a local http server that we will configure the GateClient
+// to direct requests to. Responds with the console output of instance i-0123.
+func testGateConsoleOutputSuccess(console string) *httptest.Server {
+	mux := util.TestGateMuxWithVersionHandler()
+	mux.Handle("/instances/prod/us-east-1/i-0123/console", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("provider") != "aws" {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintln(w, console)
+	}))
+	return httptest.NewServer(mux)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package instance
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+	"github.com/spinnaker/spin/util"
+)
+
+type getOptions struct {
+	*instanceOptions
+	account string
+	region  string
+}
+
+var (
+	getInstanceShort   = "Get the specified instance"
+	getInstanceLong    = "Get the details of the instance with the provided id in an account and region"
+	getInstanceExample = "usage: spin instance get [options] instance-id"
+)
+
+func NewGetCmd(instanceOptions *instanceOptions) *cobra.Command {
+	options := &getOptions{
+		instanceOptions: instanceOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "get",
+		Short:   getInstanceShort,
+		Long:    getInstanceLong,
+		Example: getInstanceExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return getInstance(cmd, options, args)
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&options.account, "account", "", "account the instance runs in")
+	cmd.PersistentFlags().StringVar(&options.region, "region", "", "region, or namespace for Kubernetes, of the instance")
+
+	return cmd
+}
+
+func getInstance(cmd *cobra.Command, options *getOptions, args []string) error {
+	id, err := util.ReadArgsOrStdin(args)
+	if err != nil {
+		return err
+	}
+	if options.account == "" || options.region == "" {
+		return errors.New("one of required parameters 'account' or 'region' not set")
+	}
+
+	instance, resp, err := options.GateClient.InstanceControllerApi.GetInstanceDetailsUsingGET(options.GateClient.Context,
+		options.account,
+		id,
+		options.region,
+		map[string]interface{}{})
+	if resp != nil {
+		if resp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("Instance '%s' not found in %s/%s\n", id, options.account, options.region)
+		} else if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("Encountered an error getting instance %s, %v\n", id, gateclient.ResponseError(resp, err))
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	options.Ui.TableOutput(instance, instanceColumns)
+	return nil
+}
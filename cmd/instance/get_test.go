@@ -0,0 +1,114 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package instance
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spinnaker/spin/cmd"
+	"github.com/spinnaker/spin/util"
+)
+
+func TestInstanceGet_table(t *testing.T) {
+	ts := testGateInstanceGetSuccess()
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, options := cmd.NewCmdRoot(buffer, buffer)
+	rootCmd.AddCommand(NewInstanceCmd(options))
+
+	args := []string{"instance", "get", "--account", "prod", "--region", "us-east-1", "i-0123", "-o", "wide", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	expected := `NAME     HEALTH   ZONE         LAUNCHED               HEALTH PROVIDERS   HEALTH STATES
+i-0123   Down     us-east-1a   2020-06-02T12:00:00Z   Amazon,Discovery   Unknown,Down`
+	recieved := strings.TrimSpace(buffer.String())
+	if expected != recieved {
+		t.Fatalf("Unexpected table output:\n%s", recieved)
+	}
+}
+
+func TestInstanceGet_flags(t *testing.T) {
+	ts := testGateInstanceGetSuccess()
+	defer ts.Close()
+
+	rootCmd, options := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewInstanceCmd(options))
+
+	args := []string{"instance", "get", "--account", "prod", "i-0123", "--gate-endpoint=" + ts.URL} // Missing region.
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Expected failure but command succeeded")
+	}
+}
+
+func TestInstanceGet_fail(t *testing.T) {
+	ts := testGateFail()
+	defer ts.Close()
+
+	rootCmd, options := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewInstanceCmd(options))
+
+	args := []string{"instance", "get", "--account", "prod", "--region", "us-east-1", "i-0123", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Expected failure but command succeeded")
+	}
+}
+
+// testGateInstanceGetSuccess spins up a local http server that we will configure the GateClient
+// to direct requests to. Responds with the details of instance i-0123.
+func testGateInstanceGetSuccess() *httptest.Server {
+	mux := util.TestGateMuxWithVersionHandler()
+	mux.Handle("/instances/prod/us-east-1/i-0123", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, strings.TrimSpace(instanceJson))
+	}))
+	return httptest.NewServer(mux)
+}
+
+// testGateFail spins up a local http server that we will configure the GateClient
+// to direct requests to. Responds with a 500 InternalServerError.
+func testGateFail() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}))
+}
+
+const instanceJson = `
+{
+  "name": "i-0123",
+  "instanceId": "i-0123",
+  "healthState": "Down",
+  "zone": "us-east-1a",
+  "launchTime": 1591099200000,
+  "instanceType": "m5.large",
+  "health": [
+    {"type": "Amazon", "state": "Unknown"},
+    {"type": "Discovery", "state": "Down", "status": "OUT_OF_SERVICE"}
+  ]
+}
+`
@@ -0,0 +1,60 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package instance
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd"
+	"github.com/spinnaker/spin/cmd/output"
+)
+
+type instanceOptions struct {
+	*cmd.RootOptions
+}
+
+var (
+	instanceShort   = ""
+	instanceLong    = ""
+	instanceExample = ""
+)
+
+func NewInstanceCmd(rootOptions *cmd.RootOptions) *cobra.Command {
+	options := &instanceOptions{
+		RootOptions: rootOptions,
+	}
+	cmd := &cobra.Command{
+		Use:     "instance",
+		Aliases: []string{"instances", "inst"},
+		Short:   instanceShort,
+		Long:    instanceLong,
+		Example: instanceExample,
+	}
+
+	// create subcommands
+	cmd.AddCommand(NewConsoleOutputCmd(options))
+	cmd.AddCommand(NewGetCmd(options))
+	cmd.AddCommand(NewListCmd(options))
+	return cmd
+}
+
+// instanceColumns are the columns of table output.
+var instanceColumns = []output.Column{
+	{Header: "NAME", Path: "{.name}"},
+	{Header: "HEALTH", Path: "{.healthState}"},
+	{Header: "ZONE", Path: "{.zone}"},
+	{Header: "LAUNCHED", Path: "{.launchTime}", Timestamp: true},
+	{Header: "HEALTH PROVIDERS", Path: "{.health[*].type}", Wide: true},
+	{Header: "HEALTH STATES", Path: "{.health[*].state}", Wide: true},
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package instance
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+	"github.com/spinnaker/spin/util"
+)
+
+type listOptions struct {
+	*instanceOptions
+	application string
+	account     string
+	region      string
+}
+
+var (
+	listInstanceShort   = "List the instances of a server group"
+	listInstanceLong    = "List the instances of the specified server group with their health"
+	listInstanceExample = "usage: spin instance list [options] server-group-name"
+)
+
+func NewListCmd(instanceOptions *instanceOptions) *cobra.Command {
+	options := &listOptions{
+		instanceOptions: instanceOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   listInstanceShort,
+		Long:    listInstanceLong,
+		Example: listInstanceExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listInstances(cmd, options, args)
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.application, "application", "a", "", "Spinnaker application the server group belongs to")
+	cmd.PersistentFlags().StringVar(&options.account, "account", "", "account the server group is deployed to")
+	cmd.PersistentFlags().StringVar(&options.region, "region", "", "region, or namespace for Kubernetes, of the server group")
+
+	return cmd
+}
+
+func listInstances(cmd *cobra.Command, options *listOptions, args []string) error {
+	serverGroupName, err := util.ReadArgsOrStdin(args)
+	if err != nil {
+		return err
+	}
+	if options.application == "" {
+		options.application = options.GateClient.DefaultApplication()
+	}
+	if options.application == "" || options.account == "" || options.region == "" {
+		return errors.New("one of required parameters 'application', 'account' or 'region' not set")
+	}
+
+	serverGroup, resp, err := options.GateClient.ServerGroupControllerApi.GetServerGroupDetailsUsingGET(options.GateClient.Context,
+		options.account,
+		options.application,
+		options.region,
+		serverGroupName,
+		map[string]interface{}{})
+	if resp != nil {
+		if resp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("Server group '%s' not found in %s/%s\n", serverGroupName, options.account, options.region)
+		} else if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("Encountered an error getting server group %s, %v\n", serverGroupName, gateclient.ResponseError(resp, err))
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	instances := []interface{}{}
+	if details, ok := serverGroup.(map[string]interface{}); ok {
+		if list, ok := details["instances"].([]interface{}); ok {
+			instances = list
+		}
+	}
+	options.Ui.TableOutput(instances, instanceColumns)
+	return nil
+}
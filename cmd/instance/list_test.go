@@ -0,0 +1,116 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package instance
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spinnaker/spin/cmd"
+	"github.com/spinnaker/spin/util"
+)
+
+func TestInstanceList_table(t *testing.T) {
+	ts := testGateInstanceListSuccess()
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, options := cmd.NewCmdRoot(buffer, buffer)
+	rootCmd.AddCommand(NewInstanceCmd(options))
+
+	args := []string{"instance", "list", "-a", "app", "--account", "prod", "--region", "us-east-1", "app-main-v002", "-o", "table", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	expected := `NAME     HEALTH   ZONE         LAUNCHED
+i-0123   Down     us-east-1a   2020-06-02T12:00:00Z
+i-4567   Up       us-east-1b   2020-06-02T12:01:00Z`
+	recieved := strings.TrimSpace(buffer.String())
+	if expected != recieved {
+		t.Fatalf("Unexpected table output:\n%s", recieved)
+	}
+}
+
+func TestInstanceList_flags(t *testing.T) {
+	ts := testGateInstanceListSuccess()
+	defer ts.Close()
+
+	rootCmd, options := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewInstanceCmd(options))
+
+	args := []string{"instance", "list", "--account", "prod", "--region", "us-east-1", "app-main-v002", "--gate-endpoint=" + ts.URL} // Missing application.
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Expected failure but command succeeded")
+	}
+}
+
+func TestInstanceList_notfound(t *testing.T) {
+	ts := testGateInstanceListSuccess()
+	defer ts.Close()
+
+	rootCmd, options := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewInstanceCmd(options))
+
+	args := []string{"instance", "list", "-a", "app", "--account", "prod", "--region", "us-east-1", "app-main-v009", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("Expected not found error, got: %v", err)
+	}
+}
+
+// testGateInstanceListSuccess spins up a local http server that we will configure the GateClient
+// to direct requests to. Responds with the server group app-main-v002 and a 404 for any other.
+func testGateInstanceListSuccess() *httptest.Server {
+	mux := util.TestGateMuxWithVersionHandler()
+	mux.Handle("/applications/app/serverGroups/prod/us-east-1/app-main-v002", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, strings.TrimSpace(serverGroupJson))
+	}))
+	mux.Handle("/applications/app/serverGroups/prod/us-east-1/", http.NotFoundHandler())
+	return httptest.NewServer(mux)
+}
+
+const serverGroupJson = `
+{
+  "name": "app-main-v002",
+  "region": "us-east-1",
+  "instances": [
+    {
+      "name": "i-0123",
+      "healthState": "Down",
+      "zone": "us-east-1a",
+      "launchTime": 1591099200000,
+      "health": [{"type": "Amazon", "state": "Unknown"}, {"type": "Discovery", "state": "Down"}]
+    },
+    {
+      "name": "i-4567",
+      "healthState": "Up",
+      "zone": "us-east-1b",
+      "launchTime": 1591099260000,
+      "health": [{"type": "Amazon", "state": "Unknown"}, {"type": "Discovery", "state": "Up"}]
+    }
+  ]
+}
+`
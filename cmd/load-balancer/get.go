@@ -0,0 +1,104 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package load_balancer
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+	"github.com/spinnaker/spin/util"
+)
+
+type getOptions struct {
+	*loadBalancerOptions
+	account  string
+	region   string
+	provider string
+}
+
+var (
+	getLoadBalancerShort   = "Get the specified load balancer"
+	getLoadBalancerLong    = "Get the details of the specified load balancer in an account and region"
+	getLoadBalancerExample = "usage: spin load-balancer get [options] load-balancer-name"
+)
+
+func NewGetCmd(loadBalancerOptions *loadBalancerOptions) *cobra.Command {
+	options := &getOptions{
+		loadBalancerOptions: loadBalancerOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "get",
+		Short:   getLoadBalancerShort,
+		Long:    getLoadBalancerLong,
+		Example: getLoadBalancerExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return getLoadBalancer(cmd, options, args)
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&options.account, "account", "", "account of the load balancer")
+	cmd.PersistentFlags().StringVar(&options.region, "region", "", "region of the load balancer")
+	cmd.PersistentFlags().StringVar(&options.provider, "provider", "", "cloud provider of the load balancer (default aws)")
+
+	return cmd
+}
+
+func getLoadBalancer(cmd *cobra.Command, options *getOptions, args []string) error {
+	name, err := util.ReadArgsOrStdin(args)
+	if err != nil {
+		return err
+	}
+	if options.account == "" || options.region == "" {
+		return errors.New("one of required parameters 'account' or 'region' not set")
+	}
+
+	query := map[string]interface{}{}
+	if options.provider != "" {
+		query["provider"] = options.provider
+	}
+
+	details, resp, err := options.GateClient.LoadBalancerControllerApi.GetLoadBalancerDetailsUsingGET(options.GateClient.Context,
+		options.account,
+		name,
+		options.region,
+		query)
+	if resp != nil {
+		if resp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("Load balancer '%s' not found in %s/%s\n", name, options.account, options.region)
+		} else if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("Encountered an error getting load balancer %s, %v\n", name, gateclient.ResponseError(resp, err))
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+	if len(details) == 0 {
+		return fmt.Errorf("Load balancer '%s' not found in %s/%s\n", name, options.account, options.region)
+	}
+
+	// Gate responds with a list, of one load balancer unless the name is
+	// used in several VPCs.
+	if len(details) == 1 {
+		options.Ui.JsonOutput(details[0])
+	} else {
+		options.Ui.JsonOutput(details)
+	}
+	return nil
+}
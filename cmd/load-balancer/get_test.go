@@ -0,0 +1,93 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package load_balancer
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spinnaker/spin/cmd"
+	"github.com/spinnaker/spin/util"
+)
+
+func TestLoadBalancerGet_basic(t *testing.T) {
+	ts := testGateLoadBalancerGetSuccess()
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, options := cmd.NewCmdRoot(buffer, ioutil.Discard)
+	rootCmd.AddCommand(NewLoadBalancerCmd(options))
+
+	args := []string{"load-balancer", "get", "--account", "prod", "--region", "us-east-1", "app-frontend", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	recieved := strings.TrimSpace(buffer.String())
+	if !strings.HasPrefix(recieved, "{") || !strings.Contains(recieved, `"loadBalancerName": "app-frontend"`) {
+		t.Fatalf("Expected the load balancer details in output:\n%s", recieved)
+	}
+}
+
+func TestLoadBalancerGet_notfound(t *testing.T) {
+	ts := testGateLoadBalancerGetSuccess()
+	defer ts.Close()
+
+	rootCmd, options := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewLoadBalancerCmd(options))
+
+	args := []string{"load-balancer", "get", "--account", "prod", "--region", "us-east-1", "missing", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("Expected not found error, got: %v", err)
+	}
+}
+
+func TestLoadBalancerGet_flags(t *testing.T) {
+	ts := testGateLoadBalancerGetSuccess()
+	defer ts.Close()
+
+	rootCmd, options := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewLoadBalancerCmd(options))
+
+	args := []string{"load-balancer", "get", "--account", "prod", "app-frontend", "--gate-endpoint=" + ts.URL} // Missing region.
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Expected failure but command succeeded")
+	}
+}
+
+// testGateLoadBalancerGetSuccess spins up a local http server that we will configure the GateClient
+// to direct requests to. Responds with the details of app-frontend, and no details for any other
+// load balancer, as Gate does.
+func testGateLoadBalancerGetSuccess() *httptest.Server {
+	mux := util.TestGateMuxWithVersionHandler()
+	mux.Handle("/loadBalancers/prod/us-east-1/app-frontend", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `[{"loadBalancerName": "app-frontend", "dnsname": "app-frontend-123.elb.amazonaws.com", "vpcid": "vpc-0001"}]`)
+	}))
+	mux.Handle("/loadBalancers/prod/us-east-1/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `[]`)
+	}))
+	return httptest.NewServer(mux)
+}
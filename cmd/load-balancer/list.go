@@ -0,0 +1,150 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package load_balancer
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+	"github.com/spinnaker/spin/cmd/output"
+)
+
+type listOptions struct {
+	*loadBalancerOptions
+	application string
+	account     string
+	region      string
+	provider    string
+}
+
+var (
+	listLoadBalancerShort   = "List load balancers"
+	listLoadBalancerLong    = "List the load balancers of a cloud provider, or with --application those of an application, optionally only those in an account and region"
+	listLoadBalancerExample = "usage: spin load-balancer list [options]"
+)
+
+// loadBalancerColumns are the columns of table output.
+var loadBalancerColumns = []output.Column{
+	{Header: "NAME", Path: "{.name}"},
+	{Header: "ACCOUNT", Path: "{.account}"},
+	{Header: "REGION", Path: "{.region}"},
+	{Header: "PROVIDER", Path: "{.type}"},
+	{Header: "SERVER GROUPS", Path: "{.serverGroups[*].name}", Wide: true},
+}
+
+func NewListCmd(loadBalancerOptions *loadBalancerOptions) *cobra.Command {
+	options := &listOptions{
+		loadBalancerOptions: loadBalancerOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   listLoadBalancerShort,
+		Long:    listLoadBalancerLong,
+		Example: listLoadBalancerExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listLoadBalancers(cmd, options)
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.application, "application", "a", "", "only list the load balancers of this Spinnaker application")
+	cmd.PersistentFlags().StringVar(&options.account, "account", "", "only list the load balancers in this account")
+	cmd.PersistentFlags().StringVar(&options.region, "region", "", "only list the load balancers in this region")
+	cmd.PersistentFlags().StringVar(&options.provider, "provider", "", "only list the load balancers of this cloud provider (default aws without --application)")
+
+	return cmd
+}
+
+func listLoadBalancers(cmd *cobra.Command, options *listOptions) error {
+	var loadBalancers []interface{}
+	var resp *http.Response
+	var err error
+	if options.application != "" {
+		loadBalancers, resp, err = options.GateClient.LoadBalancerControllerApi.GetApplicationLoadBalancersUsingGET(options.GateClient.Context, options.application, map[string]interface{}{})
+	} else {
+		query := map[string]interface{}{}
+		if options.provider != "" {
+			query["provider"] = options.provider
+		}
+		var summaries []interface{}
+		summaries, resp, err = options.GateClient.LoadBalancerControllerApi.GetAllUsingGET(options.GateClient.Context, query)
+		loadBalancers = flattenLoadBalancers(summaries)
+	}
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Encountered an error listing load balancers, %v\n", gateclient.ResponseError(resp, err))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	filtered := []interface{}{}
+	for _, lb := range loadBalancers {
+		loadBalancer, ok := lb.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if options.account != "" && loadBalancer["account"] != options.account {
+			continue
+		}
+		if options.region != "" && loadBalancer["region"] != options.region {
+			continue
+		}
+		if options.provider != "" && loadBalancer["type"] != options.provider && loadBalancer["cloudProvider"] != options.provider {
+			continue
+		}
+		filtered = append(filtered, loadBalancer)
+	}
+
+	options.Ui.TableOutput(filtered, loadBalancerColumns)
+	return nil
+}
+
+// flattenLoadBalancers lists the load balancers of Gate's summaries, which
+// group them by name, account and region.
+func flattenLoadBalancers(summaries []interface{}) []interface{} {
+	loadBalancers := []interface{}{}
+	for _, s := range summaries {
+		summary, _ := s.(map[string]interface{})
+		accounts, _ := summary["accounts"].([]interface{})
+		for _, a := range accounts {
+			account, _ := a.(map[string]interface{})
+			regions, _ := account["regions"].([]interface{})
+			for _, r := range regions {
+				region, _ := r.(map[string]interface{})
+				lbs, _ := region["loadBalancers"].([]interface{})
+				for _, l := range lbs {
+					lb, ok := l.(map[string]interface{})
+					if !ok {
+						continue
+					}
+					loadBalancer := map[string]interface{}{
+						"name":    summary["name"],
+						"account": account["name"],
+						"region":  region["name"],
+					}
+					for k, v := range lb {
+						loadBalancer[k] = v
+					}
+					loadBalancers = append(loadBalancers, loadBalancer)
+				}
+			}
+		}
+	}
+	return loadBalancers
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package load_balancer
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spinnaker/spin/cmd"
+	"github.com/spinnaker/spin/util"
+)
+
+func TestLoadBalancerList_application(t *testing.T) {
+	ts := testGateLoadBalancerListSuccess()
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, options := cmd.NewCmdRoot(buffer, buffer)
+	rootCmd.AddCommand(NewLoadBalancerCmd(options))
+
+	args := []string{"load-balancer", "list", "-a", "app", "--region", "us-east-1", "-o", "wide", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	expected := `NAME           ACCOUNT   REGION      PROVIDER   SERVER GROUPS
+app-frontend   prod      us-east-1   aws        app-main-v001,app-main-v002
+app-internal   test      us-east-1   aws`
+	recieved := strings.TrimSpace(buffer.String())
+	if expected != recieved {
+		t.Fatalf("Unexpected table output:\n%s", recieved)
+	}
+}
+
+func TestLoadBalancerList_all(t *testing.T) {
+	ts := testGateLoadBalancerListSuccess()
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, options := cmd.NewCmdRoot(buffer, buffer)
+	rootCmd.AddCommand(NewLoadBalancerCmd(options))
+
+	args := []string{"load-balancer", "list", "--account", "prod", "--provider", "aws", "-o", "table", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	expected := `NAME           ACCOUNT   REGION      PROVIDER
+app-frontend   prod      us-east-1   aws
+app-frontend   prod      us-west-2   aws
+other          prod      us-east-1   aws`
+	recieved := strings.TrimSpace(buffer.String())
+	if expected != recieved {
+		t.Fatalf("Unexpected table output:\n%s", recieved)
+	}
+}
+
+func TestLoadBalancerList_fail(t *testing.T) {
+	ts := testGateFail()
+	defer ts.Close()
+
+	rootCmd, options := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewLoadBalancerCmd(options))
+
+	args := []string{"load-balancer", "list", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Expected failure but command succeeded")
+	}
+}
+
+// testGateLoadBalancerListSuccess spins up a local http server that we will configure the GateClient
+// to direct requests to. Responds with the load balancers of the application, and the summaries of
+// the aws load balancers.
+func testGateLoadBalancerListSuccess() *httptest.Server {
+	mux := util.TestGateMuxWithVersionHandler()
+	mux.Handle("/applications/app/loadBalancers", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, strings.TrimSpace(applicationLoadBalancersJson))
+	}))
+	mux.Handle("/loadBalancers", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("provider") != "aws" {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintln(w, strings.TrimSpace(loadBalancerSummariesJson))
+	}))
+	return httptest.NewServer(mux)
+}
+
+// testGateFail spins up a local http server that we will configure the GateClient
+// to direct requests to. Responds with a 500 InternalServerError.
+func testGateFail() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}))
+}
+
+const applicationLoadBalancersJson = `
+[
+  {
+    "name": "app-frontend",
+    "account": "prod",
+    "region": "us-east-1",
+    "type": "aws",
+    "cloudProvider": "aws",
+    "serverGroups": [{"name": "app-main-v001"}, {"name": "app-main-v002"}]
+  },
+  {
+    "name": "app-frontend",
+    "account": "prod",
+    "region": "us-west-2",
+    "type": "aws",
+    "cloudProvider": "aws",
+    "serverGroups": []
+  },
+  {
+    "name": "app-internal",
+    "account": "test",
+    "region": "us-east-1",
+    "type": "aws",
+    "cloudProvider": "aws"
+  }
+]
+`
+
+const loadBalancerSummariesJson = `
+[
+  {
+    "name": "app-frontend",
+    "accounts": [
+      {
+        "name": "prod",
+        "regions": [
+          {"name": "us-east-1", "loadBalancers": [{"account": "prod", "region": "us-east-1", "name": "app-frontend", "type": "aws"}]},
+          {"name": "us-west-2", "loadBalancers": [{"account": "prod", "region": "us-west-2", "name": "app-frontend", "type": "aws"}]}
+        ]
+      }
+    ]
+  },
+  {
+    "name": "other",
+    "accounts": [
+      {
+        "name": "prod",
+        "regions": [
+          {"name": "us-east-1", "loadBalancers": [{"account": "prod", "region": "us-east-1", "name": "other", "type": "aws"}]}
+        ]
+      },
+      {
+        "name": "test",
+        "regions": [
+          {"name": "us-east-1", "loadBalancers": [{"account": "test", "region": "us-east-1", "name": "other", "type": "aws"}]}
+        ]
+      }
+    ]
+  }
+]
+`
@@ -0,0 +1,48 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package load_balancer
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd"
+)
+
+type loadBalancerOptions struct {
+	*cmd.RootOptions
+}
+
+var (
+	loadBalancerShort   = ""
+	loadBalancerLong    = ""
+	loadBalancerExample = ""
+)
+
+func NewLoadBalancerCmd(rootOptions *cmd.RootOptions) *cobra.Command {
+	options := &loadBalancerOptions{
+		RootOptions: rootOptions,
+	}
+	cmd := &cobra.Command{
+		Use:     "load-balancer",
+		Aliases: []string{"load-balancers", "lb"},
+		Short:   loadBalancerShort,
+		Long:    loadBalancerLong,
+		Example: loadBalancerExample,
+	}
+
+	// create subcommands
+	cmd.AddCommand(NewGetCmd(options))
+	cmd.AddCommand(NewListCmd(options))
+	return cmd
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package network
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+	"github.com/spinnaker/spin/cmd/output"
+)
+
+type listOptions struct {
+	*networkOptions
+	provider string
+	account  string
+	region   string
+}
+
+var (
+	listNetworkShort   = "List networks"
+	listNetworkLong    = "List networks, optionally only those of a cloud provider, account and region"
+	listNetworkExample = "usage: spin network list [options]"
+)
+
+// networkColumns are the columns of table output.
+var networkColumns = []output.Column{
+	{Header: "ID", Path: "{.id}"},
+	{Header: "NAME", Path: "{.name}"},
+	{Header: "ACCOUNT", Path: "{.account}"},
+	{Header: "REGION", Path: "{.region}"},
+	{Header: "PROVIDER", Path: "{.cloudProvider}", Wide: true},
+	{Header: "DEPRECATED", Path: "{.deprecated}", Wide: true},
+}
+
+func NewListCmd(networkOptions *networkOptions) *cobra.Command {
+	options := &listOptions{
+		networkOptions: networkOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   listNetworkShort,
+		Long:    listNetworkLong,
+		Example: listNetworkExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listNetworks(cmd, options)
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&options.provider, "provider", "", "only list the networks of this cloud provider")
+	cmd.PersistentFlags().StringVar(&options.account, "account", "", "only list the networks in this account")
+	cmd.PersistentFlags().StringVar(&options.region, "region", "", "only list the networks in this region")
+
+	return cmd
+}
+
+func listNetworks(cmd *cobra.Command, options *listOptions) error {
+	var networks []interface{}
+	var resp *http.Response
+	var err error
+	if options.provider != "" {
+		networks, resp, err = options.GateClient.NetworkControllerApi.AllByCloudProviderUsingGET(options.GateClient.Context, options.provider, map[string]interface{}{})
+	} else {
+		// Grouped by cloud provider.
+		var byProvider map[string]interface{}
+		byProvider, resp, err = options.GateClient.NetworkControllerApi.AllUsingGET2(options.GateClient.Context, map[string]interface{}{})
+		for _, list := range byProvider {
+			if list, ok := list.([]interface{}); ok {
+				networks = append(networks, list...)
+			}
+		}
+	}
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Encountered an error listing networks, %v\n", gateclient.ResponseError(resp, err))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	filtered := []map[string]interface{}{}
+	for _, n := range networks {
+		network, ok := n.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if options.account != "" && network["account"] != options.account {
+			continue
+		}
+		if options.region != "" && network["region"] != options.region {
+			continue
+		}
+		filtered = append(filtered, network)
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return sortKey(filtered[i]) < sortKey(filtered[j])
+	})
+
+	options.Ui.TableOutput(filtered, networkColumns)
+	return nil
+}
+
+// sortKey orders networks by cloud provider, account, region and id.
+func sortKey(network map[string]interface{}) string {
+	return fmt.Sprintf("%v\x00%v\x00%v\x00%v", network["cloudProvider"], network["account"], network["region"], network["id"])
+}
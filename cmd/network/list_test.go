@@ -0,0 +1,123 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package network
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spinnaker/spin/cmd"
+	"github.com/spinnaker/spin/util"
+)
+
+func TestNetworkList_all(t *testing.T) {
+	ts := testGateNetworkListSuccess()
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, options := cmd.NewCmdRoot(buffer, buffer)
+	rootCmd.AddCommand(NewNetworkCmd(options))
+
+	args := []string{"network", "list", "-o", "wide", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	expected := `ID         NAME      ACCOUNT   REGION      PROVIDER   DEPRECATED
+vpc-0001   main      prod      us-east-1   aws        false
+vpc-0002   main      prod      us-west-2   aws        false
+vpc-0003   legacy    test      us-east-1   aws        true
+default    default   gce-dev   global      gce`
+	recieved := strings.TrimSpace(buffer.String())
+	if expected != recieved {
+		t.Fatalf("Unexpected table output:\n%s", recieved)
+	}
+}
+
+func TestNetworkList_provider(t *testing.T) {
+	ts := testGateNetworkListSuccess()
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, options := cmd.NewCmdRoot(buffer, buffer)
+	rootCmd.AddCommand(NewNetworkCmd(options))
+
+	args := []string{"network", "list", "--provider", "aws", "--account", "prod", "--region", "us-west-2", "-o", "table", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	expected := `ID         NAME   ACCOUNT   REGION
+vpc-0002   main   prod      us-west-2`
+	recieved := strings.TrimSpace(buffer.String())
+	if expected != recieved {
+		t.Fatalf("Unexpected table output:\n%s", recieved)
+	}
+}
+
+func TestNetworkList_fail(t *testing.T) {
+	ts := testGateFail()
+	defer ts.Close()
+
+	rootCmd, options := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewNetworkCmd(options))
+
+	args := []string{"network", "list", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Expected failure but command succeeded")
+	}
+}
+
+// testGateNetworkListSuccess spins up a local http server that we will configure the GateClient
+// to direct requests to. Responds with the networks of all cloud providers, and those of aws alone.
+func testGateNetworkListSuccess() *httptest.Server {
+	mux := util.TestGateMuxWithVersionHandler()
+	mux.Handle("/networks", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"aws": %s, "gce": %s}`, awsNetworksJson, gceNetworksJson)
+	}))
+	mux.Handle("/networks/aws", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, awsNetworksJson)
+	}))
+	return httptest.NewServer(mux)
+}
+
+// testGateFail spins up a local http server that we will configure the GateClient
+// to direct requests to. Responds with a 500 InternalServerError.
+func testGateFail() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}))
+}
+
+const awsNetworksJson = `[
+  {"cloudProvider": "aws", "id": "vpc-0003", "name": "legacy", "account": "test", "region": "us-east-1", "deprecated": true},
+  {"cloudProvider": "aws", "id": "vpc-0002", "name": "main", "account": "prod", "region": "us-west-2", "deprecated": false},
+  {"cloudProvider": "aws", "id": "vpc-0001", "name": "main", "account": "prod", "region": "us-east-1", "deprecated": false}
+]`
+
+const gceNetworksJson = `[
+  {"cloudProvider": "gce", "id": "default", "name": "default", "account": "gce-dev", "region": "global"}
+]`
@@ -0,0 +1,47 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package network
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd"
+)
+
+type networkOptions struct {
+	*cmd.RootOptions
+}
+
+var (
+	networkShort   = ""
+	networkLong    = "Networks are known as VPCs by some cloud providers."
+	networkExample = ""
+)
+
+func NewNetworkCmd(rootOptions *cmd.RootOptions) *cobra.Command {
+	options := &networkOptions{
+		RootOptions: rootOptions,
+	}
+	cmd := &cobra.Command{
+		Use:     "network",
+		Aliases: []string{"networks", "net"},
+		Short:   networkShort,
+		Long:    networkLong,
+		Example: networkExample,
+	}
+
+	// create subcommands
+	cmd.AddCommand(NewListCmd(options))
+	return cmd
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package orca_tasks
+
+// completedStatuses are the statuses of an Orca task or pipeline execution
+// that will not change anymore.
+var completedStatuses = map[string]bool{
+	"SUCCEEDED":       true,
+	"STOPPED":         true,
+	"SKIPPED":         true,
+	"TERMINAL":        true,
+	"CANCELED":        true,
+	"FAILED_CONTINUE": true,
+}
+
+// Completed reports whether the Orca task or pipeline execution has finished
+// running, successfully or not.
+func Completed(item map[string]interface{}) bool {
+	status, _ := item["status"].(string)
+	return completedStatuses[status]
+}
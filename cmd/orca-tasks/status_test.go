@@ -0,0 +1,38 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package orca_tasks
+
+import "testing"
+
+func TestCompleted(t *testing.T) {
+	tests := []struct {
+		item map[string]interface{}
+		want bool
+	}{
+		{item: map[string]interface{}{"status": "SUCCEEDED"}, want: true},
+		{item: map[string]interface{}{"status": "TERMINAL"}, want: true},
+		{item: map[string]interface{}{"status": "CANCELED"}, want: true},
+		{item: map[string]interface{}{"status": "FAILED_CONTINUE"}, want: true},
+		{item: map[string]interface{}{"status": "RUNNING"}, want: false},
+		{item: map[string]interface{}{"status": "NOT_STARTED"}, want: false},
+		{item: map[string]interface{}{}, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := Completed(tt.item); got != tt.want {
+			t.Errorf("Completed(%v) = %v, want %v", tt.item, got, tt.want)
+		}
+	}
+}
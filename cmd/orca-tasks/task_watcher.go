@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/spinnaker/spin/cmd/gateclient"
+	"github.com/spinnaker/spin/util"
 )
 
 // WaitForSuccessfulTask observes an Orca task to see if it completed successfully.
@@ -28,39 +29,27 @@ func WaitForSuccessfulTask(gateClient *gateclient.GatewayClient, taskRef map[str
 	task, resp, err := gateClient.TaskControllerApi.GetTaskUsingGET1(gateClient.Context, id)
 
 	attempts := 0
-	for (task == nil || !taskCompleted(task)) && attempts < maxAttempts {
+	for (task == nil || !Completed(task)) && attempts < maxAttempts {
 		attempts += 1
-		time.Sleep(time.Duration(attempts*attempts) * time.Second)
+		if err := util.Sleep(gateClient.Context, time.Duration(attempts*attempts)*time.Second); err != nil {
+			return err
+		}
 		id := idFromTaskRef(taskRef)
 		task, resp, err = gateClient.TaskControllerApi.GetTaskUsingGET1(gateClient.Context, id)
 	}
 
+	if resp != nil && (resp.StatusCode < 200 || resp.StatusCode > 299) {
+		return fmt.Errorf("Encountered an error getting task %s, %v\n", id, gateclient.ResponseError(resp, err))
+	}
 	if err != nil {
 		return err
 	}
-	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		return fmt.Errorf("Encountered an error saving application, status code: %d\n", resp.StatusCode)
-	}
 	if !taskSucceeded(task) {
-		return fmt.Errorf("Encountered an error saving application, task output was: %v\n", task)
+		return fmt.Errorf("Task %s did not succeed, task output was: %v\n", id, task)
 	}
 	return nil
 }
 
-func taskCompleted(task map[string]interface{}) bool {
-	taskStatus, exists := task["status"]
-	if !exists {
-		return false
-	}
-	COMPLETED := [...]string{"SUCCEEDED", "STOPPED", "SKIPPED", "TERMINAL", "FAILED_CONTINUE"}
-	for _, status := range COMPLETED {
-		if taskStatus == status {
-			return true
-		}
-	}
-	return false
-}
-
 func taskSucceeded(task map[string]interface{}) bool {
 	taskStatus, exists := task["status"]
 	if !exists {
@@ -17,14 +17,22 @@ package output
 import (
 	"fmt"
 	"io"
+	"os"
+	"strings"
 
 	"github.com/mitchellh/cli"
 	"github.com/mitchellh/colorstring"
+	"golang.org/x/crypto/ssh/terminal"
 )
 
 type Ui interface {
 	Success(message string)
 	JsonOutput(data interface{})
+	TableOutput(data interface{}, columns []Column)
+	DiffOutput(diff string)
+	Color(message, color string) string
+	IsTerminal() bool
+	Debug(message string)
 	cli.Ui
 }
 
@@ -35,14 +43,19 @@ type ColorizeUi struct {
 	ErrorColor     string
 	WarnColor      string
 	SuccessColor   string
+	DiffAddColor   string
+	DiffDelColor   string
 	Ui             cli.Ui
 	Quiet          bool
+	Terminal       bool
 	OutputFormater OutputFormater
+	TableFormat    *TableFormat
 }
 
 func NewUI(
 	quiet, color bool,
 	outputFormater OutputFormater,
+	inReader io.Reader,
 	outWriter, errWriter io.Writer,
 ) *ColorizeUi {
 	return &ColorizeUi{
@@ -55,11 +68,15 @@ func NewUI(
 		WarnColor:    "[yellow]",
 		InfoColor:    "[blue]",
 		SuccessColor: "[bold][green]",
+		DiffAddColor: "[green]",
+		DiffDelColor: "[red]",
 		Ui: &cli.BasicUi{
+			Reader:      inReader,
 			Writer:      outWriter,
 			ErrorWriter: errWriter,
 		},
 		Quiet:          quiet,
+		Terminal:       isTerminal(outWriter),
 		OutputFormater: outputFormater,
 	}
 }
@@ -85,6 +102,34 @@ func (u *ColorizeUi) JsonOutput(data interface{}) {
 	u.Output(string(output))
 }
 
+// TableOutput prints the data as a table of the specified columns if a table
+// output format is configured, and using the configured OutputFormater otherwise.
+func (u *ColorizeUi) TableOutput(data interface{}, columns []Column) {
+	if u.TableFormat == nil {
+		u.JsonOutput(data)
+		return
+	}
+	output, err := MarshalToTable(data, columns, u.TableFormat)
+	if err != nil {
+		u.Error(fmt.Sprintf("%v", err))
+	}
+	u.Output(string(output))
+}
+
+// DiffOutput prints a line diff, coloring added and removed lines.
+func (u *ColorizeUi) DiffOutput(diff string) {
+	lines := strings.Split(diff, "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "+"):
+			lines[i] = u.colorize(line, u.DiffAddColor)
+		case strings.HasPrefix(line, "-"):
+			lines[i] = u.colorize(line, u.DiffDelColor)
+		}
+	}
+	u.Ui.Output(strings.Join(lines, "\n"))
+}
+
 func (u *ColorizeUi) Success(message string) {
 	if !u.Quiet {
 		u.Ui.Info(u.colorize(message, u.SuccessColor))
@@ -107,6 +152,23 @@ func (u *ColorizeUi) Warn(message string) {
 	}
 }
 
+// Debug writes diagnostic output, such as HTTP traces, to the error writer.
+// It is neither colored nor squelched by quiet, since it is only written when
+// explicitly requested.
+func (u *ColorizeUi) Debug(message string) {
+	u.Ui.Error(message)
+}
+
+// Color applies the color to the message unless color is disabled.
+func (u *ColorizeUi) Color(message, color string) string {
+	return u.colorize(message, color)
+}
+
+// IsTerminal reports whether output is written to a terminal.
+func (u *ColorizeUi) IsTerminal() bool {
+	return u.Terminal
+}
+
 func (u *ColorizeUi) colorize(message string, color string) string {
 	if color == "" {
 		return message
@@ -114,3 +176,8 @@ func (u *ColorizeUi) colorize(message string, color string) string {
 
 	return u.Colorize.Color(fmt.Sprintf("%s%s", color, message))
 }
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && terminal.IsTerminal(int(f.Fd()))
+}
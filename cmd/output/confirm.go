@@ -0,0 +1,36 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package output
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Confirm asks the question and reports whether the user answered yes. The
+// question should end with a prompt such as "[y/N]"; anything but "y" or
+// "yes" is taken as no.
+func Confirm(ui Ui, question string) (bool, error) {
+	answer, err := ui.Ask(question)
+	if err != nil {
+		return false, fmt.Errorf("Could not read confirmation, use --yes to skip it: %v\n", err)
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
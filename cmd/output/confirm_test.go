@@ -0,0 +1,52 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package output
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		answer   string
+		expected bool
+	}{
+		{"y\n", true},
+		{" YES \n", true},
+		{"n\n", false},
+		{"\n", false},
+		{"sure\n", false},
+	}
+
+	for _, tt := range tests {
+		ui := NewUI(false, false, MarshalToJson, strings.NewReader(tt.answer), ioutil.Discard, ioutil.Discard)
+		confirmed, err := Confirm(ui, "Proceed? [y/N]")
+		if err != nil {
+			t.Fatalf("%q: Confirm failed: %v", tt.answer, err)
+		}
+		if confirmed != tt.expected {
+			t.Fatalf("%q: expected %v, got %v", tt.answer, tt.expected, confirmed)
+		}
+	}
+}
+
+func TestConfirm_noInput(t *testing.T) {
+	ui := NewUI(false, false, MarshalToJson, strings.NewReader(""), ioutil.Discard, ioutil.Discard)
+	if _, err := Confirm(ui, "Proceed? [y/N]"); err == nil || !strings.Contains(err.Error(), "--yes") {
+		t.Fatalf("Expected an error suggesting --yes, got: %v", err)
+	}
+}
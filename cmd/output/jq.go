@@ -0,0 +1,68 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/itchyny/gojq"
+)
+
+// MarshalToJqWrapper returns a MarshalToJq function that applies the specified
+// jq filter. Each result is written on its own line, with strings written raw
+// as with 'jq --raw-output'.
+// This leverages the gojq library (https://github.com/itchyny/gojq), a pure Go
+// implementation of jq (https://stedolan.github.io/jq/manual/).
+func MarshalToJqWrapper(filter string) (OutputFormater, error) {
+	query, err := gojq.Parse(filter)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse jq filter: %v", err)
+	}
+	code, err := gojq.Compile(query)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse jq filter: %v", err)
+	}
+
+	// aka MarshalToJq
+	return func(input interface{}) ([]byte, error) {
+		data, err := toJsonValue(input)
+		if err != nil {
+			return nil, err
+		}
+
+		lines := []string{}
+		iter := code.Run(data)
+		for {
+			result, ok := iter.Next()
+			if !ok {
+				break
+			}
+			if err, ok := result.(error); ok {
+				return nil, fmt.Errorf("Failed to execute jq filter %s: %v", filter, err)
+			}
+			if s, ok := result.(string); ok {
+				lines = append(lines, s)
+				continue
+			}
+			b, err := MarshalToJson(result)
+			if err != nil {
+				return nil, err
+			}
+			lines = append(lines, string(b))
+		}
+		return []byte(strings.Join(lines, "\n")), nil
+	}, nil
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package output
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const testJqInput = `
+{
+ "name": "deploy",
+ "stages": [
+  {"name": "Wait", "type": "wait", "waitTime": 30},
+  {"name": "Bake", "type": "bake"},
+  {"name": "Deploy", "type": "deploy", "clusters": [{"account": "prod"}, {"account": "staging"}]}
+ ],
+ "triggers": [],
+ "parameters": {"b": 2, "a": 1}
+}
+`
+
+func TestOutputMarshalToJq(t *testing.T) {
+	var input interface{}
+	if err := json.Unmarshal([]byte(testJqInput), &input); err != nil {
+		t.Fatalf("Failed to unmarshal test input: %s", err)
+	}
+
+	tests := []struct {
+		filter   string
+		expected string
+	}{
+		{".name", "deploy"},
+		{`."name"`, "deploy"},
+		{".stages[].name", "Wait\nBake\nDeploy"},
+		{".stages[1:][0].type", "bake"},
+		{".stages[-1].clusters[].account", "prod\nstaging"},
+		{".missing.field", "null"},
+		{".stages | length", "3"},
+		{"[.stages[] | select(.waitTime) | .name]", "[\n \"Wait\"\n]"},
+		{`.stages | map(.name) | join(",")`, "Wait,Bake,Deploy"},
+		{`.stages | map(select(.type != "wait")) | length`, "2"},
+		{".stages | sort_by(.name) | .[0].name", "Bake"},
+		{"[.stages[].waitTime // 0] | add", "30"},
+		{".parameters | keys | join(\"\")", "ab"},
+		{".parameters | to_entries | map(.key + \"=\" + (.value | tostring)) | join(\" \")", "a=1 b=2"},
+		{`{name, count: (.stages | length)} | tojson`, `{"count":3,"name":"deploy"}`},
+		{`.stages[] | if .type == "wait" then "w" elif .type == "bake" then "b" else "d" end`, "w\nb\nd"},
+		{`.stages[0].waitTime * 2 + 1`, "61"},
+		{`(.triggers | length == 0) and (.name | not | not)`, "true"},
+		{`.stages[] | .clusters[]?.account`, "prod\nstaging"},
+		{`[.stages[].name | ascii_downcase | select(test("^d"))]|first`, "deploy"},
+		{`[..|.account? // empty]|unique|length`, "2"},
+		{"empty", ""},
+		{`.stages[0] as $wait | $wait.name`, "Wait"},
+		{`reduce .stages[] as $s (0; . + 1)`, "3"},
+		{`.parameters.a = 5 | .parameters | [.a, .b] | tojson`, "[5,2]"},
+		{`del(.stages, .triggers) | keys | join(",")`, "name,parameters"},
+		{`[paths(type == "number")] | length`, "3"},
+		{`.stages | map(.waitTime // 0) | add | @text "waited \(.)s"`, "waited 30s"},
+	}
+
+	for _, tt := range tests {
+		formatFunc, err := ParseOutputFormat("jq=" + tt.filter)
+		if err != nil {
+			t.Fatalf("%s: Failed to parse output format: %s", tt.filter, err)
+		}
+		recieved, err := formatFunc(input)
+		if err != nil {
+			t.Fatalf("%s: Failed to format: %s", tt.filter, err)
+		}
+		if string(recieved) != tt.expected {
+			t.Fatalf("%s: Unexpected jq output: want=%q got=%q", tt.filter, tt.expected, recieved)
+		}
+	}
+}
+
+func TestOutputMarshalToJq_invalid(t *testing.T) {
+	for _, filter := range []string{".[", "nosuchfunction", "{a: }", `"unterminated`, "if . then 1"} {
+		if _, err := ParseOutputFormat("jq=" + filter); err == nil {
+			t.Fatalf("%s: Expected error parsing invalid filter", filter)
+		}
+	}
+
+	formatFunc, err := ParseOutputFormat("jq=.name + 1")
+	if err != nil {
+		t.Fatalf("Failed to parse output format: %s", err)
+	}
+	if _, err := formatFunc(map[string]interface{}{"name": "deploy"}); err == nil {
+		t.Fatalf("Expected error adding a number to a string")
+	}
+}
@@ -19,7 +19,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"strings"
+	"text/template"
 
 	"k8s.io/client-go/util/jsonpath"
 	"sigs.k8s.io/yaml"
@@ -28,21 +30,34 @@ import (
 type OutputFormater func(interface{}) ([]byte, error)
 
 // ParseOutputFormat returns an OutputFormater based on the specified format.
-// Accepted values include 'json', 'yaml', and 'jsonpath=PATH'.
+// Accepted values include 'json', 'yaml', 'jsonpath=PATH', 'go-template=TEMPLATE',
+// 'go-template-file=FILE', 'jq=FILTER', 'table' and 'wide'.
 // Empty string defaults to 'json'.
+// Table formats are only supported by commands that define columns for their
+// output, see Ui.TableOutput.
 // For more about JSONPath, see https://goessner.net/articles/JsonPath/
+// For more about Go templates, see https://golang.org/pkg/text/template/
+// For more about jq, see https://stedolan.github.io/jq/manual/
 func ParseOutputFormat(outputFormat string) (OutputFormater, error) {
 	switch {
 	case outputFormat == "" || outputFormat == "json":
 		return MarshalToJson, nil
 	case outputFormat == "yaml":
 		return MarshalToYaml, nil
+	case ParseTableFormat(outputFormat) != nil:
+		return unsupportedTableFormat(outputFormat), nil
 	case strings.HasPrefix(outputFormat, "jsonpath=") && outputFormat != "jsonpath=":
-		toks := strings.Split(outputFormat, "=")
-		if len(toks) != 2 {
-			return nil, errors.New(fmt.Sprintf("Failed to parse output format flag value: %s", outputFormat))
+		return MarshalToJsonPathWrapper(strings.TrimPrefix(outputFormat, "jsonpath=")), nil
+	case strings.HasPrefix(outputFormat, "go-template=") && outputFormat != "go-template=":
+		return MarshalToGoTemplateWrapper(strings.TrimPrefix(outputFormat, "go-template="))
+	case strings.HasPrefix(outputFormat, "go-template-file=") && outputFormat != "go-template-file=":
+		text, err := ioutil.ReadFile(strings.TrimPrefix(outputFormat, "go-template-file="))
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read go template file: %v", err)
 		}
-		return MarshalToJsonPathWrapper(toks[1]), nil
+		return MarshalToGoTemplateWrapper(string(text))
+	case strings.HasPrefix(outputFormat, "jq=") && outputFormat != "jq=":
+		return MarshalToJqWrapper(strings.TrimPrefix(outputFormat, "jq="))
 	default:
 		return nil, errors.New(fmt.Sprintf("Failed to parse output format flag value: %s", outputFormat))
 	}
@@ -57,7 +72,7 @@ func MarshalToJson(input interface{}) ([]byte, error) {
 }
 
 // MarshalToJsonPathWrapper returns a MarshalToJsonPath function that uses the
-// specified jsonpath expression.
+// specified jsonpath expression. Each match is written on its own line.
 // This leverages the kubernetes jsonpath library
 // (https://kubernetes.io/docs/reference/kubectl/jsonpath/).
 func MarshalToJsonPathWrapper(expression string) OutputFormater {
@@ -75,15 +90,58 @@ func MarshalToJsonPathWrapper(expression string) OutputFormater {
 			return nil, fmt.Errorf("Failed to execute jsonpath %s on input %s: %v ", expr, input, err)
 		}
 
-		if values == nil || len(values) == 0 || len(values[0]) == 0 {
+		matches := [][]byte{}
+		for _, results := range values {
+			for _, value := range results {
+				json, err := MarshalToJson(value.Interface())
+				if err != nil {
+					return nil, err
+				}
+				matches = append(matches, unquote(json))
+			}
+		}
+
+		if len(matches) == 0 {
 			return nil, errors.New(fmt.Sprintf("Error parsing value from input %v using template %s: %v ", input, expr, err))
 		}
+		return bytes.Join(matches, []byte("\n")), nil
+	}
+}
 
-		json, err := MarshalToJson(values[0][0].Interface())
+// MarshalToGoTemplateWrapper returns a MarshalToGoTemplate function that
+// executes the specified Go template. The template is applied to the JSON
+// form of the input, so fields are referred to by their JSON names.
+func MarshalToGoTemplateWrapper(text string) (OutputFormater, error) {
+	tmpl, err := template.New("go-template").Funcs(template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+	}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse go template: %v", err)
+	}
+
+	// aka MarshalToGoTemplate
+	return func(input interface{}) ([]byte, error) {
+		data, err := toJsonValue(input)
 		if err != nil {
 			return nil, err
 		}
-		return unquote(json), err
+
+		var b bytes.Buffer
+		if err := tmpl.Execute(&b, data); err != nil {
+			return nil, fmt.Errorf("Failed to execute go template: %v", err)
+		}
+		return bytes.TrimRight(b.Bytes(), "\n"), nil
+	}, nil
+}
+
+// unsupportedTableFormat returns an OutputFormater that fails, for commands
+// that output data without table columns.
+func unsupportedTableFormat(outputFormat string) OutputFormater {
+	return func(interface{}) ([]byte, error) {
+		return nil, fmt.Errorf("Output format %s is not supported by this command", outputFormat)
 	}
 }
 
@@ -95,6 +153,20 @@ func MarshalToYaml(input interface{}) ([]byte, error) {
 	return pretty, nil
 }
 
+// toJsonValue converts input to the generic values produced by decoding its
+// JSON form, so that structs and maps are handled alike.
+func toJsonValue(input interface{}) (interface{}, error) {
+	raw, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to marshal to json: %v", err)
+	}
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, fmt.Errorf("Failed to unmarshal json: %v", err)
+	}
+	return value, nil
+}
+
 func unquote(input []byte) []byte {
 	input = bytes.TrimLeft(input, "\"")
 	input = bytes.TrimRight(input, "\"")
@@ -15,6 +15,8 @@
 package output
 
 import (
+	"io/ioutil"
+	"os"
 	"strings"
 	"testing"
 
@@ -61,6 +63,87 @@ func TestOutputMarshalToJsonPath_string(t *testing.T) {
 	}
 }
 
+func TestOutputMarshalToJsonPath_allmatches(t *testing.T) {
+	formatFunc, err := ParseOutputFormat("jsonpath={.stages[*].name}{.parameterConfig[*].name}")
+	if err != nil {
+		t.Fatalf("Failed to parse output format: %s", err)
+	}
+	jsonBytes, err := formatFunc(testMultiMap)
+	if err != nil {
+		t.Fatalf("Failed to format: %s", err)
+	}
+
+	expected := "Wait\nDeploy\nfoo"
+	recieved := string(jsonBytes)
+	if recieved != expected {
+		t.Fatalf("Unexpected formatted jsonpath output: want=%q got=%q", expected, recieved)
+	}
+}
+
+func TestOutputMarshalToGoTemplate(t *testing.T) {
+	formatFunc, err := ParseOutputFormat(`go-template={{range .stages}}{{.name}}={{.waitTime}}{{"\n"}}{{end}}{{json .triggers}}`)
+	if err != nil {
+		t.Fatalf("Failed to parse output format: %s", err)
+	}
+	templateBytes, err := formatFunc(testMultiMap)
+	if err != nil {
+		t.Fatalf("Failed to format: %s", err)
+	}
+
+	expected := "Wait=30\nDeploy=<no value>\n[]"
+	recieved := string(templateBytes)
+	if recieved != expected {
+		t.Fatalf("Unexpected formatted go template output: want=%q got=%q", expected, recieved)
+	}
+}
+
+func TestOutputMarshalToGoTemplateFile(t *testing.T) {
+	tmpFile, err := ioutil.TempFile("", "template")
+	if err != nil {
+		t.Fatalf("Could not create temp file: %s", err)
+	}
+	defer os.Remove(tmpFile.Name())
+	if _, err := tmpFile.WriteString("{{.application}}/{{.name}}\n"); err != nil {
+		t.Fatalf("Could not write temp file: %s", err)
+	}
+	tmpFile.Close()
+
+	formatFunc, err := ParseOutputFormat("go-template-file=" + tmpFile.Name())
+	if err != nil {
+		t.Fatalf("Failed to parse output format: %s", err)
+	}
+	templateBytes, err := formatFunc(testMap)
+	if err != nil {
+		t.Fatalf("Failed to format: %s", err)
+	}
+
+	expected := "app/pipeline1"
+	recieved := string(templateBytes)
+	if recieved != expected {
+		t.Fatalf("Unexpected formatted go template output: want=%q got=%q", expected, recieved)
+	}
+}
+
+func TestOutputMarshalToGoTemplate_invalid(t *testing.T) {
+	if _, err := ParseOutputFormat("go-template={{.name"); err == nil {
+		t.Fatalf("Expected error parsing invalid template")
+	}
+	if _, err := ParseOutputFormat("go-template-file=/does/not/exist"); err == nil {
+		t.Fatalf("Expected error reading missing template file")
+	}
+}
+
+var testMultiMap = map[string]interface{}{
+	"parameterConfig": []map[string]interface{}{
+		{"name": "foo"},
+	},
+	"stages": []map[string]interface{}{
+		{"name": "Wait", "waitTime": 30},
+		{"name": "Deploy"},
+	},
+	"triggers": []string{},
+}
+
 // TODO(karlkfi): Validate non-primitive jsonpath outputs.
 // This behavior changed with https://github.com/spinnaker/spin/pull/241
 
@@ -0,0 +1,240 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// Column describes a column of table output.
+type Column struct {
+	// Header is the column heading, conventionally upper case.
+	Header string
+	// Path is the jsonpath expression selecting the column value from a row,
+	// e.g. '{.name}'. All matches are shown, separated by commas.
+	Path string
+	// Wide columns are only shown with '-o wide'.
+	Wide bool
+	// Timestamp columns hold epoch milliseconds and are shown in RFC3339.
+	Timestamp bool
+}
+
+// TableFormat configures table output, selected with '-o table' or '-o wide'.
+type TableFormat struct {
+	Wide      bool
+	SortBy    string
+	NoHeaders bool
+}
+
+// ParseTableFormat returns the TableFormat for the specified output format,
+// or nil if it is not a table format.
+func ParseTableFormat(outputFormat string) *TableFormat {
+	switch outputFormat {
+	case "table":
+		return &TableFormat{}
+	case "wide":
+		return &TableFormat{Wide: true}
+	default:
+		return nil
+	}
+}
+
+// MarshalToTable renders data as a table with the specified columns. A slice
+// is rendered with a row per element, anything else as a single row.
+func MarshalToTable(data interface{}, columns []Column, format *TableFormat) ([]byte, error) {
+	rows, err := tableRows(data)
+	if err != nil {
+		return nil, err
+	}
+
+	shown := []Column{}
+	for _, c := range columns {
+		if !c.Wide || format.Wide {
+			shown = append(shown, c)
+		}
+	}
+
+	cells := make([][]string, len(rows))
+	for i, row := range rows {
+		for _, c := range shown {
+			cell, err := columnValue(c, row)
+			if err != nil {
+				return nil, err
+			}
+			cells[i] = append(cells[i], cell)
+		}
+	}
+
+	if format.SortBy != "" {
+		keys, err := sortKeys(format.SortBy, columns, rows)
+		if err != nil {
+			return nil, err
+		}
+		order := make([]int, len(rows))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(i, j int) bool {
+			return lessCell(keys[order[i]], keys[order[j]])
+		})
+		sorted := make([][]string, len(cells))
+		for i, o := range order {
+			sorted[i] = cells[o]
+		}
+		cells = sorted
+	}
+
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 3, ' ', 0)
+	if !format.NoHeaders {
+		headers := []string{}
+		for _, c := range shown {
+			headers = append(headers, c.Header)
+		}
+		fmt.Fprintln(w, strings.Join(headers, "\t"))
+	}
+	for _, row := range cells {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	w.Flush()
+
+	// Empty trailing cells leave padding behind.
+	lines := strings.Split(strings.TrimRight(b.String(), "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return []byte(strings.Join(lines, "\n")), nil
+}
+
+// tableRows splits data into rows.
+func tableRows(data interface{}) ([]interface{}, error) {
+	generic, err := toJsonValue(data)
+	if err != nil {
+		return nil, err
+	}
+	if rows, ok := generic.([]interface{}); ok {
+		return rows, nil
+	}
+	return []interface{}{generic}, nil
+}
+
+// sortKeys returns the value to sort each row by. sortBy is either the header
+// of one of the columns or a jsonpath expression.
+func sortKeys(sortBy string, columns []Column, rows []interface{}) ([]string, error) {
+	column := Column{Path: sortBy}
+	if !strings.HasPrefix(sortBy, "{") {
+		found := false
+		for _, c := range columns {
+			if strings.EqualFold(c.Header, sortBy) {
+				column, found = c, true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("Failed to sort by %s: not a column or jsonpath expression", sortBy)
+		}
+	}
+
+	keys := make([]string, len(rows))
+	for i, row := range rows {
+		key, err := columnValue(column, row)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = key
+	}
+	return keys, nil
+}
+
+// lessCell orders numbers numerically and anything else as strings.
+func lessCell(a, b string) bool {
+	af, aErr := strconv.ParseFloat(a, 64)
+	bf, bErr := strconv.ParseFloat(b, 64)
+	if aErr == nil && bErr == nil {
+		return af < bf
+	}
+	return a < b
+}
+
+func columnValue(column Column, row interface{}) (string, error) {
+	jp := jsonpath.New(column.Header)
+	jp.AllowMissingKeys(true)
+	if err := jp.Parse(column.Path); err != nil {
+		return "", fmt.Errorf("Failed to parse jsonpath expression: %v", err)
+	}
+	results, err := jp.FindResults(row)
+	if err != nil {
+		return "", fmt.Errorf("Failed to execute jsonpath %s: %v", column.Path, err)
+	}
+
+	values := []string{}
+	for _, result := range results {
+		for _, value := range result {
+			if !value.IsValid() {
+				continue
+			}
+			cell := formatCell(value)
+			if column.Timestamp {
+				cell = formatTimestamp(cell)
+			}
+			if cell != "" {
+				values = append(values, cell)
+			}
+		}
+	}
+	return strings.Join(values, ","), nil
+}
+
+func formatCell(value reflect.Value) string {
+	switch v := value.Interface().(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []interface{}:
+		items := []string{}
+		for _, item := range v {
+			items = append(items, formatCell(reflect.ValueOf(&item).Elem()))
+		}
+		return strings.Join(items, ",")
+	default:
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(raw)
+	}
+}
+
+// formatTimestamp formats epoch milliseconds in RFC3339, leaving anything
+// else untouched.
+func formatTimestamp(cell string) string {
+	ms, err := strconv.ParseInt(cell, 10, 64)
+	if err != nil || ms <= 0 {
+		return cell
+	}
+	return time.Unix(0, ms*int64(time.Millisecond)).UTC().Format(time.RFC3339)
+}
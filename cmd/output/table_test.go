@@ -0,0 +1,103 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/andreyvit/diff"
+)
+
+var testColumns = []Column{
+	{Header: "NAME", Path: "{.name}"},
+	{Header: "TAGS", Path: "{.tags}"},
+	{Header: "SIZE", Path: "{.size}", Wide: true},
+	{Header: "UPDATED", Path: "{.updateTs}", Wide: true, Timestamp: true},
+}
+
+var testRows = []interface{}{
+	map[string]interface{}{"name": "bravo", "tags": []interface{}{"a", "b"}, "size": 10.0, "updateTs": "1577836800000"},
+	map[string]interface{}{"name": "alpha", "size": 9.0},
+}
+
+func TestOutputMarshalToTable(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   TableFormat
+		expected string
+	}{
+		{
+			name:   "table",
+			format: TableFormat{},
+			expected: `
+NAME    TAGS
+bravo   a,b
+alpha`,
+		},
+		{
+			name:   "wide",
+			format: TableFormat{Wide: true},
+			expected: `
+NAME    TAGS   SIZE   UPDATED
+bravo   a,b    10     2020-01-01T00:00:00Z
+alpha          9`,
+		},
+		{
+			name:   "sort by column",
+			format: TableFormat{SortBy: "name", NoHeaders: true},
+			expected: `
+alpha
+bravo   a,b`,
+		},
+		{
+			name:   "sort by jsonpath",
+			format: TableFormat{Wide: true, SortBy: "{.size}", NoHeaders: true},
+			expected: `
+alpha         9
+bravo   a,b   10   2020-01-01T00:00:00Z`,
+		},
+	}
+
+	for _, tt := range tests {
+		table, err := MarshalToTable(testRows, testColumns, &tt.format)
+		if err != nil {
+			t.Fatalf("%s: Failed to format: %s", tt.name, err)
+		}
+
+		expected := strings.TrimSpace(tt.expected)
+		recieved := strings.TrimSpace(string(table))
+		if expected != recieved {
+			t.Fatalf("%s: Unexpected table output (want- get+):\n%s", tt.name, diff.LineDiff(expected, recieved))
+		}
+	}
+}
+
+func TestOutputMarshalToTable_badsort(t *testing.T) {
+	_, err := MarshalToTable(testRows, testColumns, &TableFormat{SortBy: "missing"})
+	if err == nil {
+		t.Fatalf("Expected error sorting by unknown column")
+	}
+}
+
+func TestOutputParseOutputFormat_table(t *testing.T) {
+	formatFunc, err := ParseOutputFormat("table")
+	if err != nil {
+		t.Fatalf("Failed to parse table output format: %s", err)
+	}
+	if _, err := formatFunc(testRows); err == nil {
+		t.Fatalf("Expected error formatting without columns")
+	}
+}
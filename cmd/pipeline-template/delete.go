@@ -19,6 +19,7 @@ import (
 	"net/http"
 
 	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
 	"github.com/spinnaker/spin/util"
 )
 
@@ -66,12 +67,12 @@ func deletePipelineTemplate(cmd *cobra.Command, options *deleteOptions, args []s
 
 	_, resp, err := options.GateClient.V2PipelineTemplatesControllerApi.DeleteUsingDELETE1(options.GateClient.Context, id, queryParams)
 
-	if err != nil {
-		return err
+	if resp != nil && resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("Encountered an error deleting pipeline template, %v\n", gateclient.ResponseError(resp, err))
 	}
 
-	if resp.StatusCode != http.StatusAccepted {
-		return fmt.Errorf("Encountered an error deleting pipeline template, status code: %d\n", resp.StatusCode)
+	if err != nil {
+		return err
 	}
 
 	options.Ui.Success(fmt.Sprintf("Pipeline template %s deleted", id))
@@ -20,6 +20,7 @@ import (
 	"net/http"
 
 	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
 	"github.com/spinnaker/spin/util"
 )
 
@@ -75,14 +76,14 @@ func getPipelineTemplate(cmd *cobra.Command, options *getOptions, args []string)
 	successPayload, resp, err := options.GateClient.V2PipelineTemplatesControllerApi.GetUsingGET2(options.GateClient.Context,
 		id, queryParams)
 
-	if err != nil {
-		return err
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Encountered an error getting pipeline template with id %s, %v\n",
+			id,
+			gateclient.ResponseError(resp, err))
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("Encountered an error getting pipeline template with id %s, status code: %d\n",
-			id,
-			resp.StatusCode)
+	if err != nil {
+		return err
 	}
 
 	options.Ui.JsonOutput(successPayload)
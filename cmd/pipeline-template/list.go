@@ -19,6 +19,8 @@ import (
 	"net/http"
 
 	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+	"github.com/spinnaker/spin/cmd/output"
 )
 
 type listOptions struct {
@@ -31,6 +33,16 @@ var (
 	listPipelineTemplateLong  = "List the pipeline templates for the provided scopes"
 )
 
+// pipelineTemplateColumns are the columns of table output.
+var pipelineTemplateColumns = []output.Column{
+	{Header: "ID", Path: "{.id}"},
+	{Header: "NAME", Path: "{.metadata.name}"},
+	{Header: "OWNER", Path: "{.metadata.owner}"},
+	{Header: "SCOPES", Path: "{.metadata.scopes}", Wide: true},
+	{Header: "DESCRIPTION", Path: "{.metadata.description}", Wide: true},
+	{Header: "UPDATED", Path: "{.updateTs}", Wide: true, Timestamp: true},
+}
+
 func NewListCmd(pipelineTemplateOptions *pipelineTemplateOptions) *cobra.Command {
 	options := &listOptions{
 		pipelineTemplateOptions: pipelineTemplateOptions,
@@ -55,16 +67,16 @@ func listPipelineTemplate(cmd *cobra.Command, options *listOptions) error {
 	successPayload, resp, err := options.GateClient.V2PipelineTemplatesControllerApi.ListUsingGET1(options.GateClient.Context,
 		map[string]interface{}{"scopes": options.scopes})
 
-	if err != nil {
-		return err
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Encountered an error listing pipeline templates for scopes %v, %v\n",
+			options.scopes,
+			gateclient.ResponseError(resp, err))
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("Encountered an error listing pipeline templates for scopes %v, status code: %d\n",
-			options.scopes,
-			resp.StatusCode)
+	if err != nil {
+		return err
 	}
 
-	options.Ui.JsonOutput(successPayload)
+	options.Ui.TableOutput(successPayload, pipelineTemplateColumns)
 	return nil
 }
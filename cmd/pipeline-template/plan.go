@@ -20,6 +20,7 @@ import (
 	"net/http"
 
 	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
 	"github.com/spinnaker/spin/util"
 )
 
@@ -63,13 +64,13 @@ func planPipelineTemplate(cmd *cobra.Command, options *planOptions) error {
 
 	successPayload, resp, err := options.GateClient.V2PipelineTemplatesControllerApi.PlanUsingPOST(options.GateClient.Context, configJson)
 
-	if err != nil {
-		return err
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Encountered an error planning pipeline template config, %v\n",
+			gateclient.ResponseError(resp, err))
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("Encountered an error planning pipeline template config, status code: %d\n",
-			resp.StatusCode)
+	if err != nil {
+		return err
 	}
 
 	options.Ui.JsonOutput(successPayload)
@@ -19,6 +19,7 @@ import (
 	"net/http"
 
 	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
 	"github.com/spinnaker/spin/util"
 )
 
@@ -84,6 +85,10 @@ func savePipelineTemplate(cmd *cobra.Command, options *saveOptions) error {
 
 	_, resp, queryErr := options.GateClient.V2PipelineTemplatesControllerApi.GetUsingGET2(options.GateClient.Context, templateId, queryParams)
 
+	if resp == nil {
+		return queryErr
+	}
+
 	var saveResp *http.Response
 	var saveErr error
 	if resp.StatusCode == http.StatusOK {
@@ -91,21 +96,18 @@ func savePipelineTemplate(cmd *cobra.Command, options *saveOptions) error {
 	} else if resp.StatusCode == http.StatusNotFound {
 		saveResp, saveErr = options.GateClient.V2PipelineTemplatesControllerApi.CreateUsingPOST1(options.GateClient.Context, templateJson, queryParams)
 	} else {
-		if queryErr != nil {
-			return queryErr
-		}
-		return fmt.Errorf("Encountered an unexpected status code %d querying pipeline template with id %s\n",
-			resp.StatusCode, templateId)
+		return fmt.Errorf("Encountered an error querying pipeline template with id %s, %v\n",
+			templateId, gateclient.ResponseError(resp, queryErr))
 	}
 
-	if saveErr != nil {
-		return saveErr
+	if saveResp != nil && saveResp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("Encountered an error saving pipeline template %v, %v\n",
+			templateJson,
+			gateclient.ResponseError(saveResp, saveErr))
 	}
 
-	if saveResp.StatusCode != http.StatusAccepted {
-		return fmt.Errorf("Encountered an error saving pipeline template %v, status code: %d\n",
-			templateJson,
-			saveResp.StatusCode)
+	if saveErr != nil {
+		return saveErr
 	}
 
 	options.Ui.Success("Pipeline template save succeeded")
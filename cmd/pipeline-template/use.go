@@ -79,6 +79,9 @@ func usePipelineTemplate(cmd *cobra.Command, options *useOptions, args []string)
 
 	// Check required params
 	options.application = strings.TrimSpace(options.application)
+	if options.application == "" {
+		options.application = options.GateClient.DefaultApplication()
+	}
 	if options.application == "" {
 		return errors.New("no application name supplied, exiting")
 	}
@@ -0,0 +1,325 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package pipeline
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+	"github.com/spinnaker/spin/cmd/output"
+	"github.com/spinnaker/spin/util"
+)
+
+type applyOptions struct {
+	*PipelineOptions
+	directory string
+	prune     bool
+	yes       bool
+	dryRun    bool
+}
+
+var (
+	applyPipelineShort = "Save all pipelines in the provided directory"
+	applyPipelineLong  = `Save the changed pipelines among the JSON and YAML pipeline files found under the provided directory, optionally deleting pipelines that have no file.
+
+With --prune, the pipelines to delete are listed and must be confirmed unless --yes is set. With --dry-run, the changes are listed but not made.`
+)
+
+// serverManagedPipelineFields are set by Spinnaker when a pipeline is saved,
+// and are ignored when comparing a pipeline file to the saved pipeline.
+var serverManagedPipelineFields = []string{"updateTs", "lastModifiedBy", "index", "id"}
+
+const (
+	applyCreated   = "created"
+	applyUpdated   = "updated"
+	applyUnchanged = "unchanged"
+	applyDeleted   = "deleted"
+)
+
+type applyResult struct {
+	application string
+	name        string
+	action      string
+}
+
+func NewApplyCmd(pipelineOptions *PipelineOptions) *cobra.Command {
+	options := &applyOptions{
+		PipelineOptions: pipelineOptions,
+	}
+	cmd := &cobra.Command{
+		Use:     "apply",
+		Aliases: []string{},
+		Short:   applyPipelineShort,
+		Long:    applyPipelineLong,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return applyPipelines(cmd, options)
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.directory, "directory", "d", "", "path to the directory of pipeline files")
+	cmd.PersistentFlags().BoolVar(&options.prune, "prune", false, "delete pipelines of the applications in the directory that have no pipeline file")
+	cmd.PersistentFlags().BoolVarP(&options.yes, "yes", "y", false, "do not ask for confirmation before deleting pipelines")
+	cmd.PersistentFlags().BoolVar(&options.dryRun, "dry-run", false, "list the changes that would be made instead of making them")
+
+	return cmd
+}
+
+func applyPipelines(cmd *cobra.Command, options *applyOptions) error {
+	if options.directory == "" {
+		return errors.New("required parameter 'directory' not set")
+	}
+
+	pipelines, err := readPipelineDirectory(options)
+	if err != nil {
+		return err
+	}
+
+	// Plan all changes before making any, so that declining the deletions
+	// leaves everything as it was.
+	var results []applyResult
+	var saves []map[string]interface{}
+	applications := []string{}
+	applied := map[string]map[string]bool{}
+	for _, pipelineJson := range pipelines {
+		application := pipelineJson["application"].(string)
+		name := pipelineJson["name"].(string)
+		if applied[application] == nil {
+			applied[application] = map[string]bool{}
+			applications = append(applications, application)
+		}
+		applied[application][name] = true
+
+		action, err := planPipeline(options, pipelineJson)
+		if err != nil {
+			return err
+		}
+		if action != applyUnchanged {
+			saves = append(saves, pipelineJson)
+		}
+		results = append(results, applyResult{application: application, name: name, action: action})
+	}
+
+	var deletions []applyResult
+	if options.prune {
+		for _, application := range applications {
+			deleted, err := planPrune(options, application, applied[application])
+			if err != nil {
+				return err
+			}
+			deletions = append(deletions, deleted...)
+		}
+	}
+	results = append(results, deletions...)
+
+	if options.dryRun {
+		options.Ui.Output(formatApplyResults(results))
+		options.Ui.Success("Pipeline apply dry run, no changes made")
+		return nil
+	}
+
+	if len(deletions) > 0 && !options.yes {
+		confirmed, err := confirmDeletions(options, deletions)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return errors.New("Aborted")
+		}
+	}
+
+	for _, pipelineJson := range saves {
+		if err := saveAppliedPipeline(options, pipelineJson); err != nil {
+			return err
+		}
+	}
+	for _, deletion := range deletions {
+		if err := pruneDeletedPipeline(options, deletion.application, deletion.name); err != nil {
+			return err
+		}
+	}
+
+	options.Ui.Output(formatApplyResults(results))
+	options.Ui.Success("Pipeline apply succeeded")
+	return nil
+}
+
+// readPipelineDirectory parses and validates every pipeline file under the
+// apply directory, so that no changes are made if any file is invalid.
+func readPipelineDirectory(options *applyOptions) ([]map[string]interface{}, error) {
+	var pipelines []map[string]interface{}
+	seen := map[string]string{}
+	err := filepath.Walk(options.directory, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !isPipelineFile(path) {
+			return nil
+		}
+
+		pipelineJson, err := util.ParseJsonFromFile(path, false)
+		if err != nil {
+			return fmt.Errorf("Could not parse pipeline file %s: %v\n", path, err)
+		}
+		if err := validatePipeline(options.PipelineOptions, pipelineJson); err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+
+		key := fmt.Sprintf("%v/%v", pipelineJson["application"], pipelineJson["name"])
+		if other, exists := seen[key]; exists {
+			return fmt.Errorf("Pipeline %s is defined in both %s and %s\n", key, other, path)
+		}
+		seen[key] = path
+
+		pipelines = append(pipelines, pipelineJson)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(pipelines) == 0 {
+		return nil, fmt.Errorf("No pipeline files found in %s\n", options.directory)
+	}
+	return pipelines, nil
+}
+
+func isPipelineFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// planPipeline returns the action needed to apply the pipeline, comparing it
+// to the saved pipeline of the same name.
+func planPipeline(options *applyOptions, pipelineJson map[string]interface{}) (string, error) {
+	application := pipelineJson["application"].(string)
+	name := pipelineJson["name"].(string)
+
+	foundPipeline, err := getExistingPipeline(options.PipelineOptions, application, name)
+	if err != nil {
+		return "", err
+	}
+
+	if len(foundPipeline) == 0 {
+		return applyCreated, nil
+	}
+	if _, exists := pipelineJson["id"].(string); !exists {
+		pipelineJson["id"] = foundPipeline["id"]
+	}
+	if reflect.DeepEqual(withoutServerManagedFields(pipelineJson), withoutServerManagedFields(foundPipeline)) {
+		return applyUnchanged, nil
+	}
+	return applyUpdated, nil
+}
+
+func saveAppliedPipeline(options *applyOptions, pipelineJson map[string]interface{}) error {
+	saveResp, err := options.GateClient.PipelineControllerApi.SavePipelineUsingPOST(options.GateClient.Context, pipelineJson)
+	if saveResp != nil && saveResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Encountered an error saving pipeline %s in application %s, %v\n",
+			pipelineJson["name"],
+			pipelineJson["application"],
+			gateclient.ResponseError(saveResp, err))
+	}
+	return err
+}
+
+// planPrune returns the deletions of the application's pipelines that are
+// not in keep.
+func planPrune(options *applyOptions, application string, keep map[string]bool) ([]applyResult, error) {
+	pipelines, resp, err := options.GateClient.ApplicationControllerApi.GetPipelineConfigsForApplicationUsingGET(options.GateClient.Context, application)
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Encountered an error listing pipelines for application %s, %v\n",
+			application,
+			gateclient.ResponseError(resp, err))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var results []applyResult
+	for _, p := range pipelines {
+		pipeline, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := pipeline["name"].(string)
+		if name == "" || keep[name] {
+			continue
+		}
+		results = append(results, applyResult{application: application, name: name, action: applyDeleted})
+	}
+	return results, nil
+}
+
+// confirmDeletions lists the pipelines to delete and asks the user to confirm.
+func confirmDeletions(options *applyOptions, deletions []applyResult) (bool, error) {
+	question := "Pipelines without a pipeline file:\n"
+	for _, d := range deletions {
+		question += fmt.Sprintf("  %s/%s\n", d.application, d.name)
+	}
+	question += fmt.Sprintf("Delete %d pipeline(s)? [y/N]", len(deletions))
+	return output.Confirm(options.Ui, question)
+}
+
+func pruneDeletedPipeline(options *applyOptions, application, name string) error {
+	resp, err := options.GateClient.PipelineControllerApi.DeletePipelineUsingDELETE(options.GateClient.Context, application, name)
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Encountered an error deleting pipeline %s in application %s, %v\n",
+			name,
+			application,
+			gateclient.ResponseError(resp, err))
+	}
+	return err
+}
+
+func withoutServerManagedFields(pipeline map[string]interface{}) map[string]interface{} {
+	stripped := make(map[string]interface{}, len(pipeline))
+	for k, v := range pipeline {
+		stripped[k] = v
+	}
+	for _, field := range serverManagedPipelineFields {
+		delete(stripped, field)
+	}
+	return stripped
+}
+
+func formatApplyResults(results []applyResult) string {
+	counts := map[string]int{}
+	buf := new(bytes.Buffer)
+	w := tabwriter.NewWriter(buf, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "APPLICATION\tNAME\tRESULT")
+	for _, r := range results {
+		counts[r.action]++
+		fmt.Fprintf(w, "%s\t%s\t%s\n", r.application, r.name, r.action)
+	}
+	w.Flush()
+
+	fmt.Fprintf(buf, "\n%d created, %d updated, %d unchanged, %d deleted",
+		counts[applyCreated],
+		counts[applyUpdated],
+		counts[applyUnchanged],
+		counts[applyDeleted])
+	return buf.String()
+}
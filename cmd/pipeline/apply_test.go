@@ -0,0 +1,308 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package pipeline
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andreyvit/diff"
+	"github.com/spinnaker/spin/cmd"
+	"github.com/spinnaker/spin/util"
+)
+
+func TestPipelineApply_basic(t *testing.T) {
+	saveBuffer := new(bytes.Buffer)
+	deleteBuffer := new(bytes.Buffer)
+	ts := testGatePipelineApplySuccess(saveBuffer, deleteBuffer)
+	defer ts.Close()
+
+	dir := tempPipelineDir(map[string]string{
+		"one.json":         applyPipelineOneJsonStr,
+		"nested/two.yml":   applyPipelineTwoYamlStr,
+		"nested/README.md": "Not a pipeline.",
+	})
+	if dir == "" {
+		t.Fatal("Could not create temp pipeline directory.")
+	}
+	defer os.RemoveAll(dir)
+
+	buffer := new(bytes.Buffer)
+	rootCmd, rootOpts := cmd.NewCmdRoot(buffer, buffer)
+	pipelineCmd, _ := NewPipelineCmd(rootOpts)
+	rootCmd.AddCommand(pipelineCmd)
+
+	args := []string{"pipeline", "apply", "--directory", dir, "--prune", "--yes", "--quiet", "--gate-endpoint", ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	// Only pipeline 'two' differs from the saved pipelines.
+	if !strings.Contains(saveBuffer.String(), `"name":"two"`) || strings.Contains(saveBuffer.String(), `"name":"one"`) {
+		t.Fatalf("Unexpected pipelines saved: %s", saveBuffer.String())
+	}
+	if deleteBuffer.String() != "/pipelines/app/three\n" {
+		t.Fatalf("Unexpected pipelines deleted: %s", deleteBuffer.String())
+	}
+
+	expected := strings.TrimSpace(applyOutput)
+	recieved := strings.TrimSpace(buffer.String())
+	if expected != recieved {
+		t.Fatalf("Unexpected command output:\n%s", diff.LineDiff(expected, recieved))
+	}
+}
+
+func TestPipelineApply_confirm(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{name: "confirmed", input: "y\n"},
+		{name: "declined", input: "n\n", wantErr: true},
+		{name: "no input", input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saveBuffer := new(bytes.Buffer)
+			deleteBuffer := new(bytes.Buffer)
+			ts := testGatePipelineApplySuccess(saveBuffer, deleteBuffer)
+			defer ts.Close()
+
+			dir := tempPipelineDir(map[string]string{
+				"one.json":       applyPipelineOneJsonStr,
+				"nested/two.yml": applyPipelineTwoYamlStr,
+			})
+			if dir == "" {
+				t.Fatal("Could not create temp pipeline directory.")
+			}
+			defer os.RemoveAll(dir)
+
+			buffer := new(bytes.Buffer)
+			rootCmd, rootOpts := cmd.NewCmdRoot(buffer, buffer)
+			pipelineCmd, _ := NewPipelineCmd(rootOpts)
+			rootCmd.AddCommand(pipelineCmd)
+			rootCmd.SetIn(strings.NewReader(tt.input))
+
+			args := []string{"pipeline", "apply", "--directory", dir, "--prune", "--gate-endpoint", ts.URL}
+			rootCmd.SetArgs(args)
+			err := rootCmd.Execute()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !strings.Contains(buffer.String(), "  app/three\n") {
+				t.Fatalf("Planned deletions not listed:\n%s", buffer.String())
+			}
+			if tt.wantErr {
+				// Declining leaves every pipeline as it was, including the saves.
+				if saveBuffer.Len() != 0 || deleteBuffer.Len() != 0 {
+					t.Fatalf("Pipelines changed without confirmation, saved: %s, deleted: %s", saveBuffer.String(), deleteBuffer.String())
+				}
+				return
+			}
+			if deleteBuffer.String() != "/pipelines/app/three\n" {
+				t.Fatalf("Unexpected pipelines deleted: %s", deleteBuffer.String())
+			}
+		})
+	}
+}
+
+func TestPipelineApply_dryRun(t *testing.T) {
+	saveBuffer := new(bytes.Buffer)
+	deleteBuffer := new(bytes.Buffer)
+	ts := testGatePipelineApplySuccess(saveBuffer, deleteBuffer)
+	defer ts.Close()
+
+	dir := tempPipelineDir(map[string]string{
+		"one.json":       applyPipelineOneJsonStr,
+		"nested/two.yml": applyPipelineTwoYamlStr,
+	})
+	if dir == "" {
+		t.Fatal("Could not create temp pipeline directory.")
+	}
+	defer os.RemoveAll(dir)
+
+	buffer := new(bytes.Buffer)
+	rootCmd, rootOpts := cmd.NewCmdRoot(buffer, buffer)
+	pipelineCmd, _ := NewPipelineCmd(rootOpts)
+	rootCmd.AddCommand(pipelineCmd)
+
+	args := []string{"pipeline", "apply", "--directory", dir, "--prune", "--dry-run", "--quiet", "--gate-endpoint", ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	if saveBuffer.Len() != 0 || deleteBuffer.Len() != 0 {
+		t.Fatalf("Pipelines changed in a dry run, saved: %s, deleted: %s", saveBuffer.String(), deleteBuffer.String())
+	}
+
+	expected := strings.TrimSpace(applyOutput)
+	recieved := strings.TrimSpace(buffer.String())
+	if expected != recieved {
+		t.Fatalf("Unexpected command output:\n%s", diff.LineDiff(expected, recieved))
+	}
+}
+
+func TestPipelineApply_invalid(t *testing.T) {
+	saveBuffer := new(bytes.Buffer)
+	ts := testGatePipelineApplySuccess(saveBuffer, new(bytes.Buffer))
+	defer ts.Close()
+
+	dir := tempPipelineDir(map[string]string{
+		"one.json":     applyPipelineOneJsonStr,
+		"missing.json": missingNameJsonStr,
+	})
+	if dir == "" {
+		t.Fatal("Could not create temp pipeline directory.")
+	}
+	defer os.RemoveAll(dir)
+
+	rootCmd, rootOpts := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	pipelineCmd, _ := NewPipelineCmd(rootOpts)
+	rootCmd.AddCommand(pipelineCmd)
+
+	args := []string{"pipeline", "apply", "--directory", dir, "--gate-endpoint", ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+	if saveBuffer.Len() != 0 {
+		t.Fatalf("Pipelines saved despite invalid pipeline file: %s", saveBuffer.String())
+	}
+}
+
+func TestPipelineApply_flags(t *testing.T) {
+	ts := testGateSuccess()
+	defer ts.Close()
+
+	rootCmd, rootOpts := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	pipelineCmd, _ := NewPipelineCmd(rootOpts)
+	rootCmd.AddCommand(pipelineCmd)
+
+	args := []string{"pipeline", "apply", "--gate-endpoint", ts.URL} // Missing directory.
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+}
+
+func TestPipelineApply_fail(t *testing.T) {
+	ts := testGateFail()
+	defer ts.Close()
+
+	dir := tempPipelineDir(map[string]string{"one.json": applyPipelineOneJsonStr})
+	if dir == "" {
+		t.Fatal("Could not create temp pipeline directory.")
+	}
+	defer os.RemoveAll(dir)
+
+	rootCmd, rootOpts := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	pipelineCmd, _ := NewPipelineCmd(rootOpts)
+	rootCmd.AddCommand(pipelineCmd)
+
+	args := []string{"pipeline", "apply", "--directory", dir, "--gate-endpoint", ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+}
+
+func tempPipelineDir(files map[string]string) string {
+	dir, err := ioutil.TempDir("" /* /tmp dir. */, "pipeline-dir")
+	if err != nil {
+		return ""
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return ""
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			return ""
+		}
+	}
+	return dir
+}
+
+// testGatePipelineApplySuccess spins up a local http server that we will configure the
+// GateClient to direct requests to. Pipeline 'one' is saved and unchanged, 'two' is
+// saved with different stages and 'three' is only saved on the server.
+// Writes saved pipeline bodies to saveBuffer and deleted pipeline paths to
+// deleteBuffer for testing.
+func testGatePipelineApplySuccess(saveBuffer, deleteBuffer *bytes.Buffer) *httptest.Server {
+	mux := util.TestGateMuxWithVersionHandler()
+	mux.Handle("/applications/app/pipelineConfigs", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `[{"name": "one"}, {"name": "two"}, {"name": "three"}]`)
+	}))
+	mux.Handle("/applications/app/pipelineConfigs/one", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"id": "id1", "application": "app", "name": "one", "stages": [], "index": 0, "updateTs": "1520879791608"}`)
+	}))
+	mux.Handle("/applications/app/pipelineConfigs/two", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"id": "id2", "application": "app", "name": "two", "stages": []}`)
+	}))
+	mux.Handle("/pipelines", util.NewTestBufferHandlerFunc(http.MethodPost, saveBuffer, http.StatusOK, ""))
+	mux.Handle("/pipelines/app/three", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		fmt.Fprintln(deleteBuffer, r.URL.Path)
+	}))
+	return httptest.NewServer(mux)
+}
+
+const applyPipelineOneJsonStr = `
+{
+  "application": "app",
+  "name": "one",
+  "stages": []
+}
+`
+
+const applyPipelineTwoYamlStr = `
+application: app
+name: two
+stages:
+- name: Wait
+  refId: "1"
+  requisiteStageRefIds: []
+  type: wait
+  waitTime: 30
+`
+
+const applyOutput = `
+APPLICATION   NAME    RESULT
+app           two     updated
+app           one     unchanged
+app           three   deleted
+
+0 created, 1 updated, 1 unchanged, 1 deleted
+`
@@ -20,6 +20,7 @@ import (
 	"net/http"
 
 	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
 )
 
 type deleteOptions struct {
@@ -55,17 +56,20 @@ func NewDeleteCmd(pipelineOptions *PipelineOptions) *cobra.Command {
 }
 
 func deletePipeline(cmd *cobra.Command, options *deleteOptions) error {
+	if options.application == "" {
+		options.application = options.GateClient.DefaultApplication()
+	}
 	if options.application == "" || options.name == "" {
 		return errors.New("one of required parameters 'application' or 'name' not set")
 	}
 	resp, err := options.GateClient.PipelineControllerApi.DeletePipelineUsingDELETE(options.GateClient.Context, options.application, options.name)
 
-	if err != nil {
-		return err
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Encountered an error deleting pipeline, %v\n", gateclient.ResponseError(resp, err))
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("Encountered an error deleting pipeline, status code: %d\n", resp.StatusCode)
+	if err != nil {
+		return err
 	}
 
 	options.Ui.Success("Pipeline deleted")
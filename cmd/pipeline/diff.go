@@ -0,0 +1,258 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package pipeline
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/andreyvit/diff"
+	"github.com/spf13/cobra"
+
+	"github.com/spinnaker/spin/cmd/output"
+	"github.com/spinnaker/spin/util"
+)
+
+type diffOptions struct {
+	*PipelineOptions
+	pipelineFile string
+	structural   bool
+}
+
+var (
+	diffPipelineShort = "Diff the provided pipeline against the saved pipeline"
+	diffPipelineLong  = "Show the changes that saving the provided pipeline would make to the saved pipeline of the same name. Exits non-zero if there are differences."
+)
+
+// diffContextLines is the number of unchanged lines shown around each change
+// in a unified diff.
+const diffContextLines = 3
+
+func NewDiffCmd(pipelineOptions *PipelineOptions) *cobra.Command {
+	options := &diffOptions{
+		PipelineOptions: pipelineOptions,
+	}
+	cmd := &cobra.Command{
+		Use:     "diff",
+		Aliases: []string{},
+		Short:   diffPipelineShort,
+		Long:    diffPipelineLong,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return diffPipeline(cmd, options)
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.pipelineFile, "file", "f", "", "path to the pipeline file")
+	cmd.PersistentFlags().BoolVar(&options.structural, "structural", false, "list changed JSON paths instead of a unified diff")
+
+	return cmd
+}
+
+func diffPipeline(cmd *cobra.Command, options *diffOptions) error {
+	pipelineJson, err := util.ParseJsonFromFileOrStdin(options.pipelineFile, false)
+	if err != nil {
+		return err
+	}
+	if err := validatePipeline(options.PipelineOptions, pipelineJson); err != nil {
+		return err
+	}
+	application := pipelineJson["application"].(string)
+	pipelineName := pipelineJson["name"].(string)
+
+	foundPipeline, err := getExistingPipeline(options.PipelineOptions, application, pipelineName)
+	if err != nil {
+		return err
+	}
+
+	fileLabel := options.pipelineFile
+	if fileLabel == "" {
+		fileLabel = "stdin"
+	}
+	changes, err := pipelineDiff(
+		fmt.Sprintf("%s/%s", application, pipelineName), withoutServerManagedFields(foundPipeline),
+		fileLabel, withoutServerManagedFields(pipelineJson),
+		options.structural)
+	if err != nil {
+		return err
+	}
+
+	if changes == "" {
+		options.Ui.Success(fmt.Sprintf("Pipeline %s in application %s is up to date", pipelineName, application))
+		return nil
+	}
+	options.Ui.DiffOutput(changes)
+	return fmt.Errorf("Pipeline %s in application %s differs from %s\n", pipelineName, application, fileLabel)
+}
+
+// pipelineDiff returns a unified or structural diff between two pipeline
+// configs, or an empty string if they are equal.
+func pipelineDiff(fromLabel string, from map[string]interface{}, toLabel string, to map[string]interface{}, structural bool) (string, error) {
+	if reflect.DeepEqual(from, to) {
+		return "", nil
+	}
+	if structural {
+		return strings.Join(structuralDiff("", from, to), "\n"), nil
+	}
+
+	fromJson, err := output.MarshalToJson(from)
+	if err != nil {
+		return "", err
+	}
+	toJson, err := output.MarshalToJson(to)
+	if err != nil {
+		return "", err
+	}
+	return unifiedDiff(fromLabel, string(fromJson), toLabel, string(toJson)), nil
+}
+
+// unifiedDiff returns a unified line diff of from and to, with
+// diffContextLines of context around each hunk.
+func unifiedDiff(fromLabel, from, toLabel, to string) string {
+	lines := diff.LineDiffAsLines(from, to)
+
+	// Line numbers in from and to of each diff line, counting from 1.
+	fromLineNos := make([]int, len(lines)+1)
+	toLineNos := make([]int, len(lines)+1)
+	fromLineNo, toLineNo := 1, 1
+	var changed []int
+	for i, line := range lines {
+		fromLineNos[i], toLineNos[i] = fromLineNo, toLineNo
+		switch line[0] {
+		case '-':
+			fromLineNo++
+			changed = append(changed, i)
+		case '+':
+			toLineNo++
+			changed = append(changed, i)
+		default:
+			fromLineNo++
+			toLineNo++
+		}
+	}
+	if len(changed) == 0 {
+		return ""
+	}
+
+	out := []string{"--- " + fromLabel, "+++ " + toLabel}
+	for h := 0; h < len(changed); {
+		start := changed[h] - diffContextLines
+		if start < 0 {
+			start = 0
+		}
+		end := changed[h] + diffContextLines + 1
+		for h++; h < len(changed) && changed[h]-diffContextLines <= end; h++ {
+			end = changed[h] + diffContextLines + 1
+		}
+		if end > len(lines) {
+			end = len(lines)
+		}
+
+		fromCount, toCount := 0, 0
+		for _, line := range lines[start:end] {
+			if line[0] != '+' {
+				fromCount++
+			}
+			if line[0] != '-' {
+				toCount++
+			}
+		}
+		out = append(out, fmt.Sprintf("@@ -%s +%s @@",
+			hunkRange(fromLineNos[start], fromCount),
+			hunkRange(toLineNos[start], toCount)))
+		out = append(out, lines[start:end]...)
+	}
+	return strings.Join(out, "\n")
+}
+
+func hunkRange(start, count int) string {
+	if count == 0 {
+		// An empty range refers to the line before the hunk.
+		start--
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+// structuralDiff lists the JSON paths under path whose values differ between
+// from and to, as removed ("-") and added ("+") lines.
+func structuralDiff(path string, from, to interface{}) []string {
+	switch f := from.(type) {
+	case map[string]interface{}:
+		if t, ok := to.(map[string]interface{}); ok {
+			keys := map[string]bool{}
+			for k := range f {
+				keys[k] = true
+			}
+			for k := range t {
+				keys[k] = true
+			}
+			sorted := make([]string, 0, len(keys))
+			for k := range keys {
+				sorted = append(sorted, k)
+			}
+			sort.Strings(sorted)
+
+			var changes []string
+			for _, k := range sorted {
+				childPath := k
+				if path != "" {
+					childPath = path + "." + k
+				}
+				fv, inFrom := f[k]
+				tv, inTo := t[k]
+				switch {
+				case !inFrom:
+					changes = append(changes, "+ "+childPath+": "+compactJson(tv))
+				case !inTo:
+					changes = append(changes, "- "+childPath+": "+compactJson(fv))
+				default:
+					changes = append(changes, structuralDiff(childPath, fv, tv)...)
+				}
+			}
+			return changes
+		}
+	case []interface{}:
+		if t, ok := to.([]interface{}); ok {
+			var changes []string
+			for i := 0; i < len(f) || i < len(t); i++ {
+				childPath := fmt.Sprintf("%s[%d]", path, i)
+				switch {
+				case i >= len(f):
+					changes = append(changes, "+ "+childPath+": "+compactJson(t[i]))
+				case i >= len(t):
+					changes = append(changes, "- "+childPath+": "+compactJson(f[i]))
+				default:
+					changes = append(changes, structuralDiff(childPath, f[i], t[i])...)
+				}
+			}
+			return changes
+		}
+	}
+
+	if reflect.DeepEqual(from, to) {
+		return nil
+	}
+	return []string{"- " + path + ": " + compactJson(from), "+ " + path + ": " + compactJson(to)}
+}
+
+func compactJson(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
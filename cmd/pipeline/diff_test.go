@@ -0,0 +1,204 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package pipeline
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/andreyvit/diff"
+	"github.com/spinnaker/spin/cmd"
+	"github.com/spinnaker/spin/util"
+)
+
+func TestPipelineDiff_unchanged(t *testing.T) {
+	ts := testGatePipelineDiffSuccess()
+	defer ts.Close()
+
+	tempFile := tempPipelineFile(diffPipelineJsonStr)
+	if tempFile == nil {
+		t.Fatal("Could not create temp pipeline file.")
+	}
+	defer os.Remove(tempFile.Name())
+
+	rootCmd, rootOpts := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	pipelineCmd, _ := NewPipelineCmd(rootOpts)
+	rootCmd.AddCommand(pipelineCmd)
+
+	args := []string{"pipeline", "diff", "--file", tempFile.Name(), "--gate-endpoint", ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+}
+
+func TestPipelineDiff_unified(t *testing.T) {
+	ts := testGatePipelineDiffSuccess()
+	defer ts.Close()
+
+	tempFile := tempPipelineFile(strings.Replace(diffPipelineJsonStr, `"waitTime": 30`, `"waitTime": 60`, 1))
+	if tempFile == nil {
+		t.Fatal("Could not create temp pipeline file.")
+	}
+	defer os.Remove(tempFile.Name())
+
+	buffer := new(bytes.Buffer)
+	rootCmd, rootOpts := cmd.NewCmdRoot(buffer, buffer)
+	pipelineCmd, _ := NewPipelineCmd(rootOpts)
+	rootCmd.AddCommand(pipelineCmd)
+
+	args := []string{"pipeline", "diff", "--file", tempFile.Name(), "--no-color=false", "--gate-endpoint", ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	expected := strings.TrimSpace(fmt.Sprintf(diffUnifiedOutput, tempFile.Name()))
+	recieved := strings.TrimSpace(buffer.String())
+	if expected != recieved {
+		t.Fatalf("Unexpected command output:\n%s", diff.LineDiff(expected, recieved))
+	}
+}
+
+func TestPipelineDiff_structural(t *testing.T) {
+	ts := testGatePipelineDiffSuccess()
+	defer ts.Close()
+
+	tempFile := tempPipelineFile(strings.Replace(diffPipelineJsonStr, `"waitTime": 30`, `"waitTime": 60`, 1))
+	if tempFile == nil {
+		t.Fatal("Could not create temp pipeline file.")
+	}
+	defer os.Remove(tempFile.Name())
+
+	buffer := new(bytes.Buffer)
+	rootCmd, rootOpts := cmd.NewCmdRoot(buffer, buffer)
+	pipelineCmd, _ := NewPipelineCmd(rootOpts)
+	rootCmd.AddCommand(pipelineCmd)
+
+	args := []string{"pipeline", "diff", "--file", tempFile.Name(), "--structural", "--no-color=false", "--gate-endpoint", ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	expected := strings.TrimSpace(diffStructuralOutput)
+	recieved := strings.TrimSpace(buffer.String())
+	if expected != recieved {
+		t.Fatalf("Unexpected command output:\n%s", diff.LineDiff(expected, recieved))
+	}
+}
+
+func TestPipelineDiff_fail(t *testing.T) {
+	ts := testGateFail()
+	defer ts.Close()
+
+	tempFile := tempPipelineFile(diffPipelineJsonStr)
+	if tempFile == nil {
+		t.Fatal("Could not create temp pipeline file.")
+	}
+	defer os.Remove(tempFile.Name())
+
+	rootCmd, rootOpts := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	pipelineCmd, _ := NewPipelineCmd(rootOpts)
+	rootCmd.AddCommand(pipelineCmd)
+
+	args := []string{"pipeline", "diff", "--file", tempFile.Name(), "--gate-endpoint", ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+}
+
+// testGatePipelineDiffSuccess spins up a local http server that we will configure the
+// GateClient to direct requests to. Responds with the saved pipeline, including the
+// fields Spinnaker manages.
+func testGatePipelineDiffSuccess() *httptest.Server {
+	mux := util.TestGateMuxWithVersionHandler()
+	mux.Handle("/applications/app/pipelineConfigs/pipeline1", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, strings.TrimSpace(diffSavedPipelineJsonStr))
+	}))
+	return httptest.NewServer(mux)
+}
+
+const diffPipelineJsonStr = `
+{
+  "application": "app",
+  "name": "pipeline1",
+  "keepWaitingPipelines": false,
+  "limitConcurrent": true,
+  "stages": [
+    {
+      "name": "Wait",
+      "refId": "1",
+      "requisiteStageRefIds": [],
+      "type": "wait",
+      "waitTime": 30
+    }
+  ],
+  "triggers": []
+}
+`
+
+const diffSavedPipelineJsonStr = `
+{
+  "application": "app",
+  "id": "pipeline1",
+  "index": 0,
+  "keepWaitingPipelines": false,
+  "lastModifiedBy": "anonymous",
+  "limitConcurrent": true,
+  "name": "pipeline1",
+  "stages": [
+    {
+      "name": "Wait",
+      "refId": "1",
+      "requisiteStageRefIds": [],
+      "type": "wait",
+      "waitTime": 30
+    }
+  ],
+  "triggers": [],
+  "updateTs": "1520879791608"
+}
+`
+
+const diffUnifiedOutput = `
+--- app/pipeline1
++++ %s
+@@ -9,7 +9,7 @@
+    "refId": "1",
+    "requisiteStageRefIds": [],
+    "type": "wait",
+-   "waitTime": 30
++   "waitTime": 60
+   }
+  ],
+  "triggers": []
+`
+
+const diffStructuralOutput = `
+- stages[0].waitTime: 30
++ stages[0].waitTime: 60
+`
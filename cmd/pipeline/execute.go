@@ -15,11 +15,17 @@
 package pipeline
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"path"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+	orca_tasks "github.com/spinnaker/spin/cmd/orca-tasks"
 	"github.com/spinnaker/spin/util"
 )
 
@@ -30,11 +36,20 @@ type executeOptions struct {
 	name          string
 	parameterFile string
 	artifactsFile string
+	wait          bool
+	waitTimeout   time.Duration
 }
 
 var (
 	executePipelineShort = "Execute the provided pipeline"
-	executePipelineLong  = "Execute the provided pipeline"
+	executePipelineLong  = `Execute the provided pipeline. With --wait, follow the execution until it completes and exit non-zero if it does not succeed.
+
+--wait-timeout limits the time spent waiting for the execution, which is left running when it elapses. The global --timeout limits the whole command, including triggering the pipeline.`
+)
+
+var (
+	// executionPollInterval is the delay between polls of a waited-on execution.
+	executionPollInterval = 5 * time.Second
 )
 
 func NewExecuteCmd(pipelineOptions *PipelineOptions) *cobra.Command {
@@ -55,11 +70,16 @@ func NewExecuteCmd(pipelineOptions *PipelineOptions) *cobra.Command {
 	cmd.PersistentFlags().StringVarP(&options.name, "name", "n", "", "name of the pipeline to execute")
 	cmd.PersistentFlags().StringVarP(&options.parameterFile, "parameter-file", "f", "", "file to load pipeline parameter values from")
 	cmd.PersistentFlags().StringVarP(&options.artifactsFile, "artifacts-file", "t", "", "file to load pipeline artifacts from")
+	cmd.PersistentFlags().BoolVarP(&options.wait, "wait", "w", false, "wait for the pipeline execution to complete")
+	cmd.PersistentFlags().DurationVar(&options.waitTimeout, "wait-timeout", 0, "maximum time to wait for the pipeline execution to complete (0 waits indefinitely)")
 
 	return cmd
 }
 
 func executePipeline(cmd *cobra.Command, options *executeOptions) error {
+	if options.application == "" {
+		options.application = options.GateClient.DefaultApplication()
+	}
 	if options.application == "" || options.name == "" {
 		return errors.New("one of required parameters 'application' or 'name' not set")
 	}
@@ -87,20 +107,120 @@ func executePipeline(cmd *cobra.Command, options *executeOptions) error {
 		}
 	}
 
-	resp, err := options.GateClient.PipelineControllerApi.InvokePipelineConfigUsingPOST1(options.GateClient.Context,
-		options.application,
-		options.name,
-		map[string]interface{}{"trigger": trigger})
-
+	ref, err := invokePipeline(options, trigger)
 	if err != nil {
-		return fmt.Errorf("Execute pipeline failed with response: %v and error: %s\n", resp, err)
+		return err
 	}
 
-	if resp.StatusCode != http.StatusAccepted {
-		return fmt.Errorf("Encountered an error executing pipeline, status code: %d\n", resp.StatusCode)
+	if !options.wait {
+		options.Ui.Success("Pipeline execution started")
+		return nil
 	}
 
-	options.Ui.Success("Pipeline execution started")
+	if ref == "" {
+		return errors.New("Gate did not return a reference to the started execution\n")
+	}
+	id := path.Base(ref)
+	options.Ui.Info(fmt.Sprintf("Pipeline execution %s started, waiting for completion...", id))
 
+	execution, err := waitForExecution(options, id)
+	if err != nil {
+		return err
+	}
+	options.Ui.JsonOutput(execution)
+
+	status := execution["status"]
+	switch status {
+	case "TERMINAL", "CANCELED":
+		return fmt.Errorf("Pipeline execution %s finished with status %s\n", id, status)
+	}
+	options.Ui.Success(fmt.Sprintf("Pipeline execution %s finished with status %s", id, status))
 	return nil
 }
+
+// invokePipeline triggers the pipeline and returns the reference to the
+// started execution, e.g. "/pipelines/<id>". The request is sent directly
+// since the generated Gate client discards the response body.
+func invokePipeline(options *executeOptions, trigger map[string]interface{}) (string, error) {
+	req, err := options.GateClient.NewRequest(http.MethodPost,
+		fmt.Sprintf("/pipelines/%s/%s", url.PathEscape(options.application), url.PathEscape(options.name)),
+		trigger)
+	if err != nil {
+		return "", err
+	}
+
+	var accepted struct {
+		Ref string `json:"ref"`
+	}
+	resp, err := options.GateClient.Do(req, &accepted)
+	if err != nil || resp.StatusCode != http.StatusAccepted {
+		return "", fmt.Errorf("Encountered an error executing pipeline, %v\n", gateclient.ResponseError(resp, err))
+	}
+	return accepted.Ref, nil
+}
+
+// waitForExecution polls the execution until it completes, reporting stage
+// status transitions as they are observed.
+//
+// With --wait-timeout, waiting stops with an error wrapping
+// context.DeadlineExceeded once it elapses.
+func waitForExecution(options *executeOptions, id string) (map[string]interface{}, error) {
+	ctx := options.GateClient.Context
+	if options.waitTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.waitTimeout)
+		defer cancel()
+	}
+
+	var execution map[string]interface{}
+	stageStatuses := map[string]interface{}{}
+	for {
+		payload, resp, err := options.GateClient.PipelineControllerApi.GetPipelineUsingGET(ctx, id)
+		if waitTimedOut(options, ctx) {
+			return nil, fmt.Errorf("Timed out after %s waiting for execution %s, last status: %v: %w\n",
+				options.waitTimeout, id, execution["status"], ctx.Err())
+		}
+		if resp != nil && resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("Encountered an error getting execution %s, %v\n", id, gateclient.ResponseError(resp, err))
+		}
+		if err != nil {
+			return nil, err
+		}
+		current, ok := payload.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Unexpected response getting execution %s: %v\n", id, payload)
+		}
+		execution = current
+
+		stages, _ := execution["stages"].([]interface{})
+		for _, s := range stages {
+			stage, ok := s.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			stageId := fmt.Sprintf("%v", stage["id"])
+			last, seen := stageStatuses[stageId]
+			if (seen || stage["status"] != "NOT_STARTED") && last != stage["status"] {
+				options.Ui.Info(fmt.Sprintf("Stage %v (%v): %v", stage["name"], stage["type"], stage["status"]))
+			}
+			stageStatuses[stageId] = stage["status"]
+		}
+
+		if orca_tasks.Completed(execution) {
+			return execution, nil
+		}
+		if err := util.Sleep(ctx, executionPollInterval); err != nil {
+			if waitTimedOut(options, ctx) {
+				return nil, fmt.Errorf("Timed out after %s waiting for execution %s, last status: %v: %w\n",
+					options.waitTimeout, id, execution["status"], err)
+			}
+			return nil, fmt.Errorf("Stopped waiting for execution %s, last status: %v: %w\n", id, execution["status"], err)
+		}
+	}
+}
+
+// waitTimedOut reports whether --wait-timeout elapsed, as opposed to the
+// whole command being aborted.
+func waitTimedOut(options *executeOptions, ctx context.Context) bool {
+	return ctx.Err() != nil && options.GateClient.Context.Err() == nil
+}
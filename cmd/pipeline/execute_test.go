@@ -15,6 +15,7 @@
 package pipeline
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -23,6 +24,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/spinnaker/spin/cmd"
 	gate "github.com/spinnaker/spin/gateapi"
@@ -135,6 +137,68 @@ func TestPipelineExecute_missingapp(t *testing.T) {
 	}
 }
 
+func TestPipelineExecute_wait(t *testing.T) {
+	executionPollInterval = time.Millisecond
+	ts := testGatePipelineExecuteWait("SUCCEEDED")
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, rootOpts := cmd.NewCmdRoot(buffer, buffer)
+	pipelineCmd, _ := NewPipelineCmd(rootOpts)
+	rootCmd.AddCommand(pipelineCmd)
+
+	args := []string{"pipeline", "execute", "--application", "app", "--name", "one", "--wait", "--gate-endpoint", ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	output := buffer.String()
+	for _, expected := range []string{"Stage Wait (wait): RUNNING", "Stage Wait (wait): SUCCEEDED", `"status": "SUCCEEDED"`} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("Expected %q in command output:\n%s", expected, output)
+		}
+	}
+}
+
+func TestPipelineExecute_waitterminal(t *testing.T) {
+	executionPollInterval = time.Millisecond
+	ts := testGatePipelineExecuteWait("TERMINAL")
+	defer ts.Close()
+
+	rootCmd, rootOpts := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	pipelineCmd, _ := NewPipelineCmd(rootOpts)
+	rootCmd.AddCommand(pipelineCmd)
+
+	args := []string{"pipeline", "execute", "--application", "app", "--name", "one", "--wait", "--gate-endpoint", ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+}
+
+func TestPipelineExecute_waittimeout(t *testing.T) {
+	executionPollInterval = time.Millisecond
+	ts := testGatePipelineExecuteWait("RUNNING")
+	defer ts.Close()
+
+	rootCmd, rootOpts := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	pipelineCmd, _ := NewPipelineCmd(rootOpts)
+	rootCmd.AddCommand(pipelineCmd)
+
+	args := []string{"pipeline", "execute", "--application", "app", "--name", "one", "--wait", "--wait-timeout", "10ms", "--gate-endpoint", ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "Timed out after 10ms waiting for execution exec1") {
+		t.Fatalf("Expected wait timeout, got: %v", err)
+	}
+	if code, _ := rootOpts.ExitStatus(err); code != cmd.ExitCodeTimeout {
+		t.Fatalf("Expected exit code %d, got %d", cmd.ExitCodeTimeout, code)
+	}
+}
+
 // testGatePipelineExecuteSuccess spins up a local http server that we will configure the GateClient
 // to direct requests to. Responds with successful responses to pipeline execute API calls.
 func testGatePipelineExecuteSuccess() *httptest.Server {
@@ -146,17 +210,31 @@ func testGatePipelineExecuteSuccess() *httptest.Server {
 		w.WriteHeader(http.StatusAccepted)
 		fmt.Fprintln(w, string(b)) // Write empty 201.
 	}))
-	mux.Handle("/applications/app/executions/search", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		fmt.Fprintln(w, strings.TrimSpace(executions))
-	}))
 	return httptest.NewServer(mux)
 }
 
-const executions = `
-[
-  {
-    "id": "asdflkj"
-  }
-]
-`
+// testGatePipelineExecuteWait spins up a local http server that accepts a manual trigger of
+// a pipeline, referring to the started execution, and reports the execution as running once,
+// then with the given final status.
+func testGatePipelineExecuteWait(finalStatus string) *httptest.Server {
+	mux := util.TestGateMuxWithVersionHandler()
+	mux.Handle("/pipelines/app/one", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var trigger map[string]interface{}
+		if r.Method != http.MethodPost || json.NewDecoder(r.Body).Decode(&trigger) != nil || trigger["type"] != "manual" {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprintln(w, `{"ref": "/pipelines/exec1"}`)
+	}))
+	polls := 0
+	mux.Handle("/pipelines/exec1", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := "RUNNING"
+		if polls > 0 {
+			status = finalStatus
+		}
+		polls++
+		fmt.Fprintf(w, `{"id": "exec1", "status": "%s", "stages": [{"id": "s1", "name": "Wait", "type": "wait", "status": "%s"}]}`, status, status)
+	}))
+	return httptest.NewServer(mux)
+}
@@ -20,6 +20,7 @@ import (
 	"net/http"
 
 	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
 	"github.com/spinnaker/spin/util"
 )
 
@@ -58,14 +59,14 @@ func cancelExecution(cmd *cobra.Command, options *cancelOptions, args []string)
 		executionId,
 		map[string]interface{}{})
 
-	if err != nil {
-		return err
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("encountered an error canceling execution with id %s, %v\n",
+			executionId,
+			gateclient.ResponseError(resp, err))
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("encountered an error canceling execution with id %s, status code: %d\n",
-			executionId,
-			resp.StatusCode)
+	if err != nil {
+		return err
 	}
 
 	options.Ui.Success(fmt.Sprintf("Execution %s successfully canceled", executionId))
@@ -0,0 +1,100 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package execution
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+	"github.com/spinnaker/spin/util"
+)
+
+// controlCommand is a command changing the state of an execution with a
+// single API call that takes only the execution id.
+type controlCommand struct {
+	use     string
+	short   string
+	long    string
+	doing   string
+	done    string
+	control func(client *gateclient.GatewayClient, executionId string) (*http.Response, error)
+}
+
+// controlCommands are the commands built by NewControlCmd.
+var controlCommands = []controlCommand{
+	{
+		use:   "pause",
+		short: "Pause the execution for the provided execution id",
+		long:  "Pause the execution for the provided execution id",
+		doing: "pausing",
+		done:  "paused",
+		control: func(client *gateclient.GatewayClient, executionId string) (*http.Response, error) {
+			return client.PipelineControllerApi.PausePipelineUsingPUT(client.Context, executionId)
+		},
+	},
+	{
+		use:   "resume",
+		short: "Resume the execution for the provided execution id",
+		long:  "Resume the execution for the provided execution id",
+		doing: "resuming",
+		done:  "resumed",
+		control: func(client *gateclient.GatewayClient, executionId string) (*http.Response, error) {
+			_, resp, err := client.PipelineControllerApi.ResumePipelineUsingPUT(client.Context, executionId)
+			return resp, err
+		},
+	},
+}
+
+func NewControlCmd(executionOptions *executionOptions, control controlCommand) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   control.use,
+		Short: control.short,
+		Long:  control.long,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return controlExecution(cmd, executionOptions, control, args)
+		},
+	}
+
+	return cmd
+}
+
+func controlExecution(cmd *cobra.Command, options *executionOptions, control controlCommand, args []string) error {
+	executionId, err := util.ReadArgsOrStdin(args)
+	if err != nil {
+		return err
+	}
+	if executionId == "" {
+		return errors.New("no execution id supplied, exiting")
+	}
+
+	resp, err := control.control(options.GateClient, executionId)
+
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("encountered an error %s execution with id %s, %v\n",
+			control.doing,
+			executionId,
+			gateclient.ResponseError(resp, err))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	options.Ui.Success(fmt.Sprintf("Execution %s successfully %s", executionId, control.done))
+	return nil
+}
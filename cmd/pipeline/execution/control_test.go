@@ -0,0 +1,117 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package execution
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spinnaker/spin/cmd"
+	"github.com/spinnaker/spin/cmd/pipeline"
+	"github.com/spinnaker/spin/util"
+)
+
+func TestExecutionControl(t *testing.T) {
+	tests := []struct {
+		command string
+		request string
+	}{
+		{command: "pause", request: "PUT /pipelines/someId/pause"},
+		{command: "resume", request: "PUT /pipelines/someId/resume"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			requests := new(bytes.Buffer)
+			ts := testGateExecutionControlSuccess(requests)
+			defer ts.Close()
+
+			rootCmd, rootOpts := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+			pipelineCmd, pipelineOpts := pipeline.NewPipelineCmd(rootOpts)
+			pipelineCmd.AddCommand(NewExecutionCmd(pipelineOpts))
+			rootCmd.AddCommand(pipelineCmd)
+
+			args := []string{"pipeline", "ex", tt.command, "someId", "--gate-endpoint", ts.URL}
+			rootCmd.SetArgs(args)
+			err := rootCmd.Execute()
+			if err != nil {
+				t.Fatalf("Command failed with: %s", err)
+			}
+			if strings.TrimSpace(requests.String()) != tt.request {
+				t.Fatalf("Unexpected request, want %q got %q", tt.request, requests.String())
+			}
+
+			// Missing execution id.
+			args = []string{"pipeline", "ex", tt.command, "--gate-endpoint", ts.URL}
+			rootCmd.SetArgs(args)
+			err = rootCmd.Execute()
+			if err == nil {
+				t.Fatalf("Expected failure without an execution id but command succeeded")
+			}
+		})
+	}
+}
+
+func TestExecutionControl_failure(t *testing.T) {
+	for _, control := range controlCommands {
+		t.Run(control.use, func(t *testing.T) {
+			ts := testGateControlFail()
+			defer ts.Close()
+
+			rootCmd, rootOpts := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+			pipelineCmd, pipelineOpts := pipeline.NewPipelineCmd(rootOpts)
+			pipelineCmd.AddCommand(NewExecutionCmd(pipelineOpts))
+			rootCmd.AddCommand(pipelineCmd)
+
+			args := []string{"pipeline", "ex", control.use, "someId", "--gate-endpoint", ts.URL}
+			rootCmd.SetArgs(args)
+			err := rootCmd.Execute()
+			if err == nil {
+				t.Fatalf("Expected failure but command succeeded")
+			}
+		})
+	}
+}
+
+// testGateExecutionControlSuccess spins up a local http server that we will configure the GateClient
+// to direct requests to. Writes the method, path and body of each pipeline request to buffer and
+// responds with a 200 and an empty object.
+func testGateExecutionControlSuccess(buffer *bytes.Buffer) *httptest.Server {
+	mux := util.TestGateMuxWithVersionHandler()
+	mux.Handle("/pipelines/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		fmt.Fprintf(buffer, "%s %s\n", r.Method, r.URL.Path)
+		if len(body) > 0 {
+			fmt.Fprintf(buffer, "%s\n", body)
+		}
+		fmt.Fprintln(w, "{}")
+	}))
+	return httptest.NewServer(mux)
+}
+
+// testGateControlFail spins up a local http server that we will configure the GateClient
+// to direct requests to. Responds with a 500 InternalServerError for pipeline requests.
+func testGateControlFail() *httptest.Server {
+	mux := util.TestGateMuxWithVersionHandler()
+	mux.Handle("/pipelines/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}))
+	return httptest.NewServer(mux)
+}
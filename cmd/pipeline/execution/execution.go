@@ -45,5 +45,11 @@ func NewExecutionCmd(pipelineOptions *pipeline.PipelineOptions) *cobra.Command {
 	cmd.AddCommand(NewCancelCmd(options))
 	cmd.AddCommand(NewGetCmd(options))
 	cmd.AddCommand(NewListCmd(options))
+	cmd.AddCommand(NewWatchCmd(options))
+	for _, control := range controlCommands {
+		cmd.AddCommand(NewControlCmd(options, control))
+	}
+	cmd.AddCommand(NewRestartStageCmd(options))
+	cmd.AddCommand(NewJudgeCmd(options))
 	return cmd
 }
@@ -19,16 +19,18 @@ import (
 	"net/http"
 
 	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
 	"github.com/spinnaker/spin/util"
 )
 
 var (
 	getExecutionShort = "Get the specified execution"
-	getExecutionLong  = "Get the execution with the provided id "
+	getExecutionLong  = "Get the execution with the provided id. Use --tree to show the stages, tasks and errors of the execution instead of the raw execution."
 )
 
 type getOptions struct {
 	*executionOptions
+	tree bool
 }
 
 func NewGetCmd(executionOptions *executionOptions) *cobra.Command {
@@ -43,6 +45,9 @@ func NewGetCmd(executionOptions *executionOptions) *cobra.Command {
 			return getExecution(cmd, options, args)
 		},
 	}
+
+	cmd.PersistentFlags().BoolVar(&options.tree, "tree", false, "render the execution as a tree of stages and tasks")
+
 	return cmd
 }
 
@@ -60,16 +65,25 @@ func getExecution(cmd *cobra.Command, options *getOptions, args []string) error
 	successPayload, resp, err := options.GateClient.ExecutionsControllerApi.GetLatestExecutionsByConfigIdsUsingGET(
 		options.GateClient.Context, query)
 
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Encountered an error getting execution %s, %v\n",
+			id,
+			gateclient.ResponseError(resp, err))
+	}
+
 	if err != nil {
 		return err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("Encountered an error getting execution %s, status code: %d\n",
-			id,
-			resp.StatusCode)
+	if !options.tree {
+		options.Ui.JsonOutput(successPayload)
+		return nil
 	}
 
-	options.Ui.JsonOutput(successPayload)
+	for _, e := range successPayload {
+		if execution, ok := e.(map[string]interface{}); ok {
+			options.Ui.Output(formatExecution(execution))
+		}
+	}
 	return nil
 }
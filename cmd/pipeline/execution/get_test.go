@@ -15,6 +15,7 @@
 package execution
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -22,6 +23,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/andreyvit/diff"
 	"github.com/spinnaker/spin/cmd"
 	"github.com/spinnaker/spin/cmd/pipeline"
 	"github.com/spinnaker/spin/util"
@@ -44,6 +46,34 @@ func TestExecutionGet_basic(t *testing.T) {
 	}
 }
 
+func TestExecutionGet_tree(t *testing.T) {
+	mux := util.TestGateMuxWithVersionHandler()
+	mux.Handle("/executions/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, strings.TrimSpace(executionFailedJson))
+	}))
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, rootOpts := cmd.NewCmdRoot(buffer, buffer)
+	pipelineCmd, pipelineOpts := pipeline.NewPipelineCmd(rootOpts)
+	pipelineCmd.AddCommand(NewExecutionCmd(pipelineOpts))
+	rootCmd.AddCommand(pipelineCmd)
+
+	args := []string{"pipeline", "ex", "get", "someId", "--tree", "--gate-endpoint", ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	expected := strings.TrimSpace(executionFailedTree)
+	recieved := strings.TrimSpace(buffer.String())
+	if expected != recieved {
+		t.Fatalf("Unexpected command output:\n%s", diff.LineDiff(expected, recieved))
+	}
+}
+
 func TestExecutionGet_noinput(t *testing.T) {
 	ts := testGateExecutionGetSuccess()
 	defer ts.Close()
@@ -168,3 +198,91 @@ const executionGetJson = `
  }
 ]
 `
+
+const executionFailedJson = `
+[
+ {
+  "id": "someId",
+  "name": "deploy",
+  "status": "TERMINAL",
+  "startTime": 1550686500000,
+  "endTime": 1550686590000,
+  "stages": [
+   {
+    "id": "s3",
+    "refId": "3",
+    "requisiteStageRefIds": ["1", "2"],
+    "name": "Deploy",
+    "type": "deploy",
+    "status": "TERMINAL",
+    "startTime": 1550686530000,
+    "endTime": 1550686590000,
+    "context": {},
+    "tasks": []
+   },
+   {
+    "id": "s1",
+    "refId": "1",
+    "requisiteStageRefIds": [],
+    "name": "Wait",
+    "type": "wait",
+    "status": "SUCCEEDED",
+    "startTime": 1550686500000,
+    "endTime": 1550686530000,
+    "context": {},
+    "tasks": [
+     {"name": "wait", "status": "SUCCEEDED", "startTime": 1550686500000, "endTime": 1550686530000}
+    ]
+   },
+   {
+    "id": "s2",
+    "refId": "2",
+    "requisiteStageRefIds": [],
+    "name": "Bake",
+    "type": "bake",
+    "status": "SKIPPED",
+    "context": {},
+    "tasks": []
+   },
+   {
+    "id": "s3a",
+    "refId": "3<1",
+    "parentStageId": "s3",
+    "syntheticStageOwner": "STAGE_BEFORE",
+    "requisiteStageRefIds": [],
+    "name": "createServerGroup",
+    "type": "createServerGroup",
+    "status": "TERMINAL",
+    "startTime": 1550686530000,
+    "endTime": 1550686590000,
+    "context": {
+     "exception": {
+      "details": {
+       "error": "Unexpected Task Failure",
+       "errors": ["Quota 'CPUS' exceeded."]
+      }
+     }
+    },
+    "tasks": [
+     {"name": "determineHealthProviders", "status": "SUCCEEDED", "startTime": 1550686530000, "endTime": 1550686531000},
+     {"name": "createServerGroup", "status": "TERMINAL", "startTime": 1550686531000, "endTime": 1550686590000}
+    ]
+   }
+  ]
+ }
+]
+`
+
+const executionFailedTree = `
+deploy (someId) TERMINAL 1m30s
+  [1] Wait (wait) SUCCEEDED 30s
+    - wait SUCCEEDED 30s
+  [2] Bake (bake) SKIPPED -
+  [3] Deploy (deploy) TERMINAL 1m0s after [1, 2]
+    [3<1] createServerGroup (createServerGroup) TERMINAL 1m0s
+      - determineHealthProviders SUCCEEDED 1s
+      - createServerGroup TERMINAL 59s
+      Failed task: createServerGroup
+      Error: Unexpected Task Failure
+      Error: Quota 'CPUS' exceeded.
+`
@@ -0,0 +1,99 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package execution
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+	"github.com/spinnaker/spin/util"
+)
+
+var (
+	judgeStageShort = "Judge a manual judgment stage of the execution for the provided execution id"
+	judgeStageLong  = "Continue or stop the execution for the provided execution id at the manual judgment stage with the provided stage id"
+)
+
+type judgeOptions struct {
+	*executionOptions
+	stageId  string
+	judgment string
+	input    string
+}
+
+func NewJudgeCmd(executionOptions *executionOptions) *cobra.Command {
+	options := &judgeOptions{
+		executionOptions: executionOptions,
+	}
+	cmd := &cobra.Command{
+		Use:   "judge",
+		Short: judgeStageShort,
+		Long:  judgeStageLong,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return judgeStage(cmd, options, args)
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&options.stageId, "stage-id", "", "id of the manual judgment stage")
+	cmd.PersistentFlags().StringVar(&options.judgment, "judgment", "", "judgment to make, either 'continue' or 'stop'")
+	cmd.PersistentFlags().StringVar(&options.input, "input", "", "judgment input, one of the options configured on the stage")
+
+	return cmd
+}
+
+func judgeStage(cmd *cobra.Command, options *judgeOptions, args []string) error {
+	executionId, err := util.ReadArgsOrStdin(args)
+	if err != nil {
+		return err
+	}
+	if executionId == "" {
+		return errors.New("no execution id supplied, exiting")
+	}
+	if options.stageId == "" {
+		return errors.New("required parameter 'stage-id' not set")
+	}
+	if options.judgment != "continue" && options.judgment != "stop" {
+		return fmt.Errorf("invalid judgment %q, must be 'continue' or 'stop'", options.judgment)
+	}
+
+	judgment := map[string]interface{}{
+		"judgmentStatus": options.judgment,
+	}
+	if options.input != "" {
+		judgment["judgmentInput"] = options.input
+	}
+
+	_, resp, err := options.GateClient.PipelineControllerApi.UpdateStageUsingPATCH(options.GateClient.Context,
+		judgment,
+		executionId,
+		options.stageId)
+
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("encountered an error judging stage %s of execution with id %s, %v\n",
+			options.stageId,
+			executionId,
+			gateclient.ResponseError(resp, err))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	options.Ui.Success(fmt.Sprintf("Stage %s of execution %s judged %s", options.stageId, executionId, options.judgment))
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package execution
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/spinnaker/spin/cmd"
+	"github.com/spinnaker/spin/cmd/pipeline"
+)
+
+func TestExecutionJudge_basic(t *testing.T) {
+	requests := new(bytes.Buffer)
+	ts := testGateExecutionControlSuccess(requests)
+	defer ts.Close()
+
+	rootCmd, rootOpts := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	pipelineCmd, pipelineOpts := pipeline.NewPipelineCmd(rootOpts)
+	pipelineCmd.AddCommand(NewExecutionCmd(pipelineOpts))
+	rootCmd.AddCommand(pipelineCmd)
+
+	args := []string{"pipeline", "ex", "judge", "someId", "--stage-id", "stageId", "--judgment", "continue", "--input", "prod", "--gate-endpoint", ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	expected := `PATCH /pipelines/someId/stages/stageId
+{"judgmentInput":"prod","judgmentStatus":"continue"}`
+	if strings.TrimSpace(requests.String()) != expected {
+		t.Fatalf("Unexpected request, want %q got %q", expected, requests.String())
+	}
+}
+
+func TestExecutionJudge_badjudgment(t *testing.T) {
+	requests := new(bytes.Buffer)
+	ts := testGateExecutionControlSuccess(requests)
+	defer ts.Close()
+
+	rootCmd, rootOpts := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	pipelineCmd, pipelineOpts := pipeline.NewPipelineCmd(rootOpts)
+	pipelineCmd.AddCommand(NewExecutionCmd(pipelineOpts))
+	rootCmd.AddCommand(pipelineCmd)
+
+	args := []string{"pipeline", "ex", "judge", "someId", "--stage-id", "stageId", "--judgment", "maybe", "--gate-endpoint", ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Command failed with: %v", err)
+	}
+	if requests.Len() != 0 {
+		t.Fatalf("Unexpected request: %s", requests.String())
+	}
+}
+
+func TestExecutionJudge_failure(t *testing.T) {
+	ts := testGateControlFail()
+	defer ts.Close()
+
+	rootCmd, rootOpts := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	pipelineCmd, pipelineOpts := pipeline.NewPipelineCmd(rootOpts)
+	pipelineCmd.AddCommand(NewExecutionCmd(pipelineOpts))
+	rootCmd.AddCommand(pipelineCmd)
+
+	args := []string{"pipeline", "ex", "judge", "someId", "--stage-id", "stageId", "--judgment", "stop", "--gate-endpoint", ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Command failed with: %v", err)
+	}
+}
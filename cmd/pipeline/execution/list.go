@@ -21,6 +21,8 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+	"github.com/spinnaker/spin/cmd/output"
 )
 
 type listOptions struct {
@@ -39,6 +41,17 @@ var (
 	listExecutionLong  = "List the executions for the provided pipeline id"
 )
 
+// executionColumns are the columns of table output.
+var executionColumns = []output.Column{
+	{Header: "ID", Path: "{.id}"},
+	{Header: "NAME", Path: "{.name}"},
+	{Header: "STATUS", Path: "{.status}"},
+	{Header: "STARTED", Path: "{.startTime}", Timestamp: true},
+	{Header: "ENDED", Path: "{.endTime}", Wide: true, Timestamp: true},
+	{Header: "TRIGGER", Path: "{.trigger.type}", Wide: true},
+	{Header: "USER", Path: "{.trigger.user}", Wide: true},
+}
+
 func NewListCmd(executionOptions *executionOptions) *cobra.Command {
 	options := &listOptions{
 		executionOptions: executionOptions,
@@ -96,16 +109,16 @@ func listExecution(cmd *cobra.Command, options *listOptions) error {
 	successPayload, resp, err := options.GateClient.ExecutionsControllerApi.GetLatestExecutionsByConfigIdsUsingGET(
 		options.GateClient.Context, query)
 
-	if err != nil {
-		return err
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Encountered an error listing executions for pipeline id %s, %v\n",
+			options.pipelineConfigId,
+			gateclient.ResponseError(resp, err))
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("Encountered an error listing executions for pipeline id %s, status code: %d\n",
-			options.pipelineConfigId,
-			resp.StatusCode)
+	if err != nil {
+		return err
 	}
 
-	options.Ui.JsonOutput(successPayload)
+	options.Ui.TableOutput(successPayload, executionColumns)
 	return nil
 }
@@ -0,0 +1,85 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package execution
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+	"github.com/spinnaker/spin/util"
+)
+
+var (
+	restartStageShort = "Restart a stage of the execution for the provided execution id"
+	restartStageLong  = "Restart the stage with the provided stage id, and the stages that depend on it, in the execution for the provided execution id"
+)
+
+type restartStageOptions struct {
+	*executionOptions
+	stageId string
+}
+
+func NewRestartStageCmd(executionOptions *executionOptions) *cobra.Command {
+	options := &restartStageOptions{
+		executionOptions: executionOptions,
+	}
+	cmd := &cobra.Command{
+		Use:   "restart-stage",
+		Short: restartStageShort,
+		Long:  restartStageLong,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return restartStage(cmd, options, args)
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&options.stageId, "stage-id", "", "id of the stage to restart")
+
+	return cmd
+}
+
+func restartStage(cmd *cobra.Command, options *restartStageOptions, args []string) error {
+	executionId, err := util.ReadArgsOrStdin(args)
+	if err != nil {
+		return err
+	}
+	if executionId == "" {
+		return errors.New("no execution id supplied, exiting")
+	}
+	if options.stageId == "" {
+		return errors.New("required parameter 'stage-id' not set")
+	}
+
+	_, resp, err := options.GateClient.PipelineControllerApi.RestartStageUsingPUT(options.GateClient.Context,
+		map[string]interface{}{},
+		executionId,
+		options.stageId)
+
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("encountered an error restarting stage %s of execution with id %s, %v\n",
+			options.stageId,
+			executionId,
+			gateclient.ResponseError(resp, err))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	options.Ui.Success(fmt.Sprintf("Stage %s of execution %s successfully restarted", options.stageId, executionId))
+	return nil
+}
@@ -0,0 +1,254 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package execution
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// failedStatuses are the execution statuses that indicate a stage or task did
+// not complete successfully.
+var failedStatuses = map[string]bool{
+	"TERMINAL":        true,
+	"FAILED_CONTINUE": true,
+	"STOPPED":         true,
+	"CANCELED":        true,
+}
+
+// timeNow is used to compute the duration of stages that are still running.
+var timeNow = time.Now
+
+// stageNode is a stage of an execution along with the synthetic stages it owns.
+type stageNode struct {
+	stage  map[string]interface{}
+	before []*stageNode
+	after  []*stageNode
+}
+
+// stageTree arranges the stages of an execution into a tree of top-level stages
+// and their synthetic before/after stages, with each level ordered by the
+// refId/requisiteStageRefIds graph.
+func stageTree(execution map[string]interface{}) []*stageNode {
+	stages, _ := execution["stages"].([]interface{})
+	nodes := []*stageNode{}
+	byId := map[string]*stageNode{}
+	for _, s := range stages {
+		stage, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		node := &stageNode{stage: stage}
+		nodes = append(nodes, node)
+		if id, ok := stage["id"].(string); ok {
+			byId[id] = node
+		}
+	}
+
+	roots := []*stageNode{}
+	for _, node := range nodes {
+		parent, ok := byId[stringField(node.stage, "parentStageId")]
+		if !ok {
+			roots = append(roots, node)
+			continue
+		}
+		if node.stage["syntheticStageOwner"] == "STAGE_AFTER" {
+			parent.after = append(parent.after, node)
+		} else {
+			parent.before = append(parent.before, node)
+		}
+	}
+
+	for _, node := range nodes {
+		node.before = sortStages(node.before)
+		node.after = sortStages(node.after)
+	}
+	return sortStages(roots)
+}
+
+// sortStages orders sibling stages so that each stage follows its requisite
+// stages, keeping the original order where the graph allows it. Stages that
+// are part of a cycle are appended in their original order.
+func sortStages(nodes []*stageNode) []*stageNode {
+	refIds := map[string]bool{}
+	for _, node := range nodes {
+		refIds[stringField(node.stage, "refId")] = true
+	}
+
+	sorted := []*stageNode{}
+	placed := map[string]bool{}
+	remaining := nodes
+	for len(remaining) > 0 {
+		next := []*stageNode{}
+		for _, node := range remaining {
+			ready := true
+			for _, ref := range requisiteRefIds(node.stage) {
+				if refIds[ref] && !placed[ref] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				sorted = append(sorted, node)
+				placed[stringField(node.stage, "refId")] = true
+			} else {
+				next = append(next, node)
+			}
+		}
+		if len(next) == len(remaining) {
+			return append(sorted, next...)
+		}
+		remaining = next
+	}
+	return sorted
+}
+
+// formatExecution renders an execution as a human readable stage tree.
+func formatExecution(execution map[string]interface{}) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (%s) %s %s\n",
+		stringField(execution, "name"),
+		stringField(execution, "id"),
+		stringField(execution, "status"),
+		formatDuration(execution))
+	for _, node := range stageTree(execution) {
+		writeStage(&b, node, 1)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func writeStage(b *strings.Builder, node *stageNode, depth int) {
+	indent := strings.Repeat("  ", depth)
+	stage := node.stage
+	fmt.Fprintf(b, "%s[%s] %s (%s) %s %s",
+		indent,
+		stringField(stage, "refId"),
+		stringField(stage, "name"),
+		stringField(stage, "type"),
+		stringField(stage, "status"),
+		formatDuration(stage))
+	if requisites := requisiteRefIds(stage); len(requisites) > 0 {
+		fmt.Fprintf(b, " after [%s]", strings.Join(requisites, ", "))
+	}
+	b.WriteString("\n")
+
+	for _, child := range node.before {
+		writeStage(b, child, depth+1)
+	}
+	tasks, _ := stage["tasks"].([]interface{})
+	for _, t := range tasks {
+		if task, ok := t.(map[string]interface{}); ok {
+			fmt.Fprintf(b, "%s  - %s %s %s\n",
+				indent,
+				stringField(task, "name"),
+				stringField(task, "status"),
+				formatDuration(task))
+		}
+	}
+	for _, child := range node.after {
+		writeStage(b, child, depth+1)
+	}
+
+	if !failedStatuses[stringField(stage, "status")] {
+		return
+	}
+	if task := failedTask(stage); task != "" {
+		fmt.Fprintf(b, "%s  Failed task: %s\n", indent, task)
+	}
+	for _, message := range stageErrors(stage) {
+		fmt.Fprintf(b, "%s  Error: %s\n", indent, message)
+	}
+}
+
+// failedTask returns the name of the first task of the stage that did not
+// complete successfully.
+func failedTask(stage map[string]interface{}) string {
+	tasks, _ := stage["tasks"].([]interface{})
+	for _, t := range tasks {
+		task, ok := t.(map[string]interface{})
+		if ok && failedStatuses[stringField(task, "status")] {
+			return stringField(task, "name")
+		}
+	}
+	return ""
+}
+
+// stageErrors collects the error messages recorded in the stage context by the
+// stage itself (context.exception) and by any clouddriver tasks it ran.
+func stageErrors(stage map[string]interface{}) []string {
+	context, _ := stage["context"].(map[string]interface{})
+	messages := []string{}
+	seen := map[string]bool{}
+	add := func(message string) {
+		if message != "" && !seen[message] {
+			seen[message] = true
+			messages = append(messages, message)
+		}
+	}
+
+	if exception, ok := context["exception"].(map[string]interface{}); ok {
+		if details, ok := exception["details"].(map[string]interface{}); ok {
+			add(stringField(details, "error"))
+			errs, _ := details["errors"].([]interface{})
+			for _, e := range errs {
+				if message, ok := e.(string); ok {
+					add(message)
+				}
+			}
+		}
+	}
+
+	katoTasks, _ := context["kato.tasks"].([]interface{})
+	for _, t := range katoTasks {
+		task, _ := t.(map[string]interface{})
+		if exception, ok := task["exception"].(map[string]interface{}); ok {
+			add(stringField(exception, "message"))
+		}
+	}
+	return messages
+}
+
+// formatDuration returns the time between startTime and endTime of a stage,
+// task or execution. Items that have not finished are measured up to now.
+func formatDuration(item map[string]interface{}) string {
+	start, ok := item["startTime"].(float64)
+	if !ok || start == 0 {
+		return "-"
+	}
+	end, ok := item["endTime"].(float64)
+	if !ok || end == 0 {
+		end = float64(timeNow().UnixNano() / int64(time.Millisecond))
+	}
+	return (time.Duration(end-start) * time.Millisecond).Round(time.Second).String()
+}
+
+func requisiteRefIds(stage map[string]interface{}) []string {
+	requisites, _ := stage["requisiteStageRefIds"].([]interface{})
+	refs := []string{}
+	for _, r := range requisites {
+		if ref, ok := r.(string); ok {
+			refs = append(refs, ref)
+		}
+	}
+	sort.Strings(refs)
+	return refs
+}
+
+func stringField(item map[string]interface{}, key string) string {
+	value, _ := item[key].(string)
+	return value
+}
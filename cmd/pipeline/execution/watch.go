@@ -0,0 +1,288 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package execution
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+	orca_tasks "github.com/spinnaker/spin/cmd/orca-tasks"
+	"github.com/spinnaker/spin/util"
+)
+
+type watchOptions struct {
+	*executionOptions
+	latest           bool
+	pipelineConfigId string
+	interval         time.Duration
+}
+
+var (
+	watchExecutionShort = "Watch the specified execution until it completes"
+	watchExecutionLong  = `Watch the execution with the provided id, or the latest execution of a pipeline with --latest, until it completes.
+
+On a terminal the stages of the execution are redrawn as they progress. Otherwise a JSON event is written per line whenever a stage starts, succeeds or fails.`
+)
+
+// stageEvents maps stage statuses to the event emitted when a stage enters them.
+var stageEvents = map[string]string{
+	"RUNNING":         "stage.started",
+	"SUCCEEDED":       "stage.succeeded",
+	"SKIPPED":         "stage.skipped",
+	"TERMINAL":        "stage.failed",
+	"FAILED_CONTINUE": "stage.failed",
+	"STOPPED":         "stage.failed",
+	"CANCELED":        "stage.failed",
+}
+
+// statusColors are the colors used for stage statuses in the terminal view.
+var statusColors = map[string]string{
+	"RUNNING":         "[blue]",
+	"SUCCEEDED":       "[green]",
+	"SKIPPED":         "[yellow]",
+	"TERMINAL":        "[red]",
+	"FAILED_CONTINUE": "[red]",
+	"STOPPED":         "[red]",
+	"CANCELED":        "[red]",
+}
+
+// watchEvent is written as a line of JSON for each stage transition when the
+// output is not a terminal.
+type watchEvent struct {
+	Time        string `json:"time"`
+	Event       string `json:"event"`
+	ExecutionId string `json:"executionId"`
+	StageId     string `json:"stageId,omitempty"`
+	RefId       string `json:"refId,omitempty"`
+	Name        string `json:"name"`
+	Type        string `json:"type,omitempty"`
+	Status      string `json:"status"`
+}
+
+func NewWatchCmd(executionOptions *executionOptions) *cobra.Command {
+	options := &watchOptions{
+		executionOptions: executionOptions,
+	}
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: watchExecutionShort,
+		Long:  watchExecutionLong,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return watchExecution(cmd, options, args)
+		},
+	}
+
+	cmd.PersistentFlags().BoolVar(&options.latest, "latest", false, "watch the latest execution of the pipeline given by --pipeline-id")
+	cmd.PersistentFlags().StringVarP(&options.pipelineConfigId, "pipeline-id", "i", "", "Spinnaker pipeline id to watch the latest execution of")
+	cmd.PersistentFlags().DurationVar(&options.interval, "interval", 5*time.Second, "time to wait between polls of the execution")
+
+	return cmd
+}
+
+func watchExecution(cmd *cobra.Command, options *watchOptions, args []string) error {
+	id, err := watchedExecutionId(options, args)
+	if err != nil {
+		return err
+	}
+
+	query := map[string]interface{}{
+		"executionIds": id,
+		"limit":        int32(1),
+	}
+	seen := map[string]string{}
+	drawnLines := 0
+	for {
+		execution, err := fetchExecution(options.executionOptions, query)
+		if err != nil {
+			return err
+		}
+		if execution == nil {
+			return fmt.Errorf("Execution %s not found", id)
+		}
+
+		completed := orca_tasks.Completed(execution)
+		switch {
+		case options.Ui.IsTerminal():
+			drawnLines = drawExecution(options, execution, drawnLines)
+		default:
+			writeStageEvents(options, execution, seen, completed)
+		}
+
+		if completed {
+			status := stringField(execution, "status")
+			if status == "TERMINAL" || status == "CANCELED" {
+				return fmt.Errorf("Execution %s finished with status %s", id, status)
+			}
+			return nil
+		}
+		if err := util.Sleep(options.GateClient.Context, options.interval); err != nil {
+			return err
+		}
+	}
+}
+
+// watchedExecutionId returns the execution id given as an argument or, with
+// --latest, the id of the most recent execution of the pipeline.
+func watchedExecutionId(options *watchOptions, args []string) (string, error) {
+	if !options.latest {
+		return util.ReadArgsOrStdin(args)
+	}
+	if options.pipelineConfigId == "" {
+		return "", errors.New("required parameter 'pipeline-id' not set")
+	}
+
+	execution, err := fetchExecution(options.executionOptions, map[string]interface{}{
+		"pipelineConfigIds": options.pipelineConfigId,
+		"limit":             int32(1),
+	})
+	if err != nil {
+		return "", err
+	}
+	if execution == nil {
+		return "", fmt.Errorf("No executions found for pipeline id %s", options.pipelineConfigId)
+	}
+	return stringField(execution, "id"), nil
+}
+
+// fetchExecution returns the first execution matching the query, or nil if
+// there is none.
+func fetchExecution(options *executionOptions, query map[string]interface{}) (map[string]interface{}, error) {
+	successPayload, resp, err := options.GateClient.ExecutionsControllerApi.GetLatestExecutionsByConfigIdsUsingGET(
+		options.GateClient.Context, query)
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Encountered an error getting execution, %v\n", gateclient.ResponseError(resp, err))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(successPayload) == 0 {
+		return nil, nil
+	}
+	execution, _ := successPayload[0].(map[string]interface{})
+	return execution, nil
+}
+
+// drawExecution draws a table of the stages of the execution, replacing the
+// previously drawn table, and returns the number of lines drawn.
+func drawExecution(options *watchOptions, execution map[string]interface{}, drawnLines int) int {
+	if options.Quiet() {
+		return 0
+	}
+
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "%s (%s)\t%s\t%s\n",
+		stringField(execution, "name"),
+		stringField(execution, "id"),
+		formatDuration(execution),
+		colorStatus(options, execution))
+	for _, row := range flattenStages(stageTree(execution), 1) {
+		fmt.Fprintf(w, "%s%s\t%s\t%s\n",
+			strings.Repeat("  ", row.depth),
+			stringField(row.stage, "name"),
+			formatDuration(row.stage),
+			colorStatus(options, row.stage))
+	}
+	w.Flush()
+
+	table := strings.TrimRight(b.String(), "\n")
+	if drawnLines > 0 {
+		// Move the cursor to the start of the previous table and clear it.
+		table = fmt.Sprintf("\033[%dA\033[J%s", drawnLines, table)
+	}
+	options.Ui.Output(table)
+	return strings.Count(table, "\n") + 1
+}
+
+// writeStageEvents writes an event for each stage whose status changed since
+// it was last seen, and one for the execution once it completes.
+func writeStageEvents(options *watchOptions, execution map[string]interface{}, seen map[string]string, completed bool) {
+	if options.Quiet() {
+		return
+	}
+
+	now := timeNow().UTC().Format(time.RFC3339)
+	executionId := stringField(execution, "id")
+	for _, row := range flattenStages(stageTree(execution), 1) {
+		stage := row.stage
+		stageId := stringField(stage, "id")
+		event := stageEvents[stringField(stage, "status")]
+		if event == "" || seen[stageId] == event {
+			continue
+		}
+		seen[stageId] = event
+		writeEvent(options, watchEvent{
+			Time:        now,
+			Event:       event,
+			ExecutionId: executionId,
+			StageId:     stageId,
+			RefId:       stringField(stage, "refId"),
+			Name:        stringField(stage, "name"),
+			Type:        stringField(stage, "type"),
+			Status:      stringField(stage, "status"),
+		})
+	}
+
+	if completed {
+		writeEvent(options, watchEvent{
+			Time:        now,
+			Event:       "execution.completed",
+			ExecutionId: executionId,
+			Name:        stringField(execution, "name"),
+			Status:      stringField(execution, "status"),
+		})
+	}
+}
+
+func writeEvent(options *watchOptions, event watchEvent) {
+	line, err := json.Marshal(event)
+	if err != nil {
+		options.Ui.Error(fmt.Sprintf("%v", err))
+		return
+	}
+	options.Ui.Output(string(line))
+}
+
+func colorStatus(options *watchOptions, item map[string]interface{}) string {
+	status := stringField(item, "status")
+	if color, ok := statusColors[status]; ok {
+		return options.Ui.Color(status, color)
+	}
+	return status
+}
+
+type stageRow struct {
+	stage map[string]interface{}
+	depth int
+}
+
+// flattenStages lists the stages of a stage tree in the order they are shown,
+// with synthetic stages nested under the stage that owns them.
+func flattenStages(nodes []*stageNode, depth int) []stageRow {
+	rows := []stageRow{}
+	for _, node := range nodes {
+		rows = append(rows, stageRow{stage: node.stage, depth: depth})
+		rows = append(rows, flattenStages(node.before, depth+1)...)
+		rows = append(rows, flattenStages(node.after, depth+1)...)
+	}
+	return rows
+}
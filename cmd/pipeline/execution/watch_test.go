@@ -0,0 +1,224 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package execution
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andreyvit/diff"
+	"github.com/spinnaker/spin/cmd"
+	"github.com/spinnaker/spin/cmd/output"
+	"github.com/spinnaker/spin/cmd/pipeline"
+	"github.com/spinnaker/spin/util"
+)
+
+func TestExecutionWatch_events(t *testing.T) {
+	defer stubTimeNow()()
+	ts := testGateExecutionWatch("SUCCEEDED")
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, rootOpts := cmd.NewCmdRoot(buffer, buffer)
+	pipelineCmd, pipelineOpts := pipeline.NewPipelineCmd(rootOpts)
+	pipelineCmd.AddCommand(NewExecutionCmd(pipelineOpts))
+	rootCmd.AddCommand(pipelineCmd)
+
+	args := []string{"pipeline", "ex", "watch", "someId", "--interval", "1ms", "--gate-endpoint", ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	expected := strings.TrimSpace(watchEvents)
+	recieved := strings.TrimSpace(buffer.String())
+	if expected != recieved {
+		t.Fatalf("Unexpected command output:\n%s", diff.LineDiff(expected, recieved))
+	}
+}
+
+func TestExecutionWatch_latest(t *testing.T) {
+	ts := testGateExecutionWatch("SUCCEEDED")
+	defer ts.Close()
+
+	rootCmd, rootOpts := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	pipelineCmd, pipelineOpts := pipeline.NewPipelineCmd(rootOpts)
+	pipelineCmd.AddCommand(NewExecutionCmd(pipelineOpts))
+	rootCmd.AddCommand(pipelineCmd)
+
+	args := []string{"pipeline", "ex", "watch", "--latest", "--pipeline-id", "pipelineId", "--interval", "1ms", "--gate-endpoint", ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+}
+
+func TestExecutionWatch_terminal(t *testing.T) {
+	ts := testGateExecutionWatch("TERMINAL")
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, rootOpts := cmd.NewCmdRoot(buffer, buffer)
+	pipelineCmd, pipelineOpts := pipeline.NewPipelineCmd(rootOpts)
+	pipelineCmd.AddCommand(NewExecutionCmd(pipelineOpts))
+	rootCmd.AddCommand(pipelineCmd)
+
+	args := []string{"pipeline", "ex", "watch", "someId", "--quiet", "--interval", "1ms", "--gate-endpoint", ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Expected failure for TERMINAL execution")
+	}
+	if buffer.Len() != 0 {
+		t.Fatalf("Unexpected output with --quiet: %s", buffer.String())
+	}
+}
+
+func TestExecutionWatch_flags(t *testing.T) {
+	ts := testGateExecutionWatch("SUCCEEDED")
+	defer ts.Close()
+
+	rootCmd, rootOpts := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	pipelineCmd, pipelineOpts := pipeline.NewPipelineCmd(rootOpts)
+	pipelineCmd.AddCommand(NewExecutionCmd(pipelineOpts))
+	rootCmd.AddCommand(pipelineCmd)
+
+	args := []string{"pipeline", "ex", "watch", "--latest", "--gate-endpoint", ts.URL} // Missing pipeline id.
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+}
+
+func TestExecutionWatch_failure(t *testing.T) {
+	ts := testGateFail()
+	defer ts.Close()
+
+	rootCmd, rootOpts := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	pipelineCmd, pipelineOpts := pipeline.NewPipelineCmd(rootOpts)
+	pipelineCmd.AddCommand(NewExecutionCmd(pipelineOpts))
+	rootCmd.AddCommand(pipelineCmd)
+
+	args := []string{"pipeline", "ex", "watch", "someId", "--gate-endpoint", ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+}
+
+func TestExecutionWatch_draw(t *testing.T) {
+	defer stubTimeNow()()
+	buffer := new(bytes.Buffer)
+	ui := output.NewUI(false, false, output.MarshalToJson, nil, buffer, buffer)
+	ui.Terminal = true
+	options := &watchOptions{
+		executionOptions: &executionOptions{
+			PipelineOptions: &pipeline.PipelineOptions{
+				RootOptions: &cmd.RootOptions{Ui: ui},
+			},
+		},
+	}
+
+	execution := map[string]interface{}{
+		"id":        "someId",
+		"name":      "deploy",
+		"status":    "RUNNING",
+		"startTime": float64(1550686500000),
+		"stages": []interface{}{
+			map[string]interface{}{"id": "s2", "refId": "2", "requisiteStageRefIds": []interface{}{"1"}, "name": "Deploy", "status": "RUNNING"},
+			map[string]interface{}{"id": "s1", "refId": "1", "name": "Wait", "status": "SUCCEEDED"},
+		},
+	}
+	drawn := drawExecution(options, execution, 0)
+	drawn = drawExecution(options, execution, drawn)
+	if drawn != 3 {
+		t.Fatalf("Expected 3 lines drawn, got %d", drawn)
+	}
+
+	table := "deploy (someId)  1m40s  RUNNING\n  Wait           -      SUCCEEDED\n  Deploy         -      RUNNING\n"
+	expected := table + "\033[3A\033[J" + table
+	if expected != buffer.String() {
+		t.Fatalf("Unexpected terminal output:\n%q", buffer.String())
+	}
+}
+
+// stubTimeNow fixes the time used for event timestamps and running stage
+// durations, returning a function that restores it.
+func stubTimeNow() func() {
+	timeNow = func() time.Time { return time.Unix(1550686600, 0) }
+	return func() { timeNow = time.Now }
+}
+
+// testGateExecutionWatch spins up a local http server that we will configure the GateClient
+// to direct requests to. The execution is running on the first request, has its second
+// stage running on the second request and has finished with the given status afterwards.
+func testGateExecutionWatch(finalStatus string) *httptest.Server {
+	var mu sync.Mutex
+	polls := 0
+	mux := util.TestGateMuxWithVersionHandler()
+	mux.Handle("/executions", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("pipelineConfigIds") == "pipelineId" {
+			fmt.Fprintln(w, `[{"id": "someId", "status": "RUNNING"}]`)
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		first, second, status := "RUNNING", "NOT_STARTED", "RUNNING"
+		switch {
+		case polls == 1:
+			first, second = "SUCCEEDED", "RUNNING"
+		case polls > 1:
+			first, second, status = "SUCCEEDED", finalStatus, finalStatus
+		}
+		polls++
+		fmt.Fprintf(w, executionWatchJson, status, first, second)
+	}))
+	return httptest.NewServer(mux)
+}
+
+const executionWatchJson = `
+[
+ {
+  "id": "someId",
+  "name": "deploy",
+  "status": "%s",
+  "startTime": 1550686500000,
+  "stages": [
+   {"id": "s1", "refId": "1", "requisiteStageRefIds": [], "name": "Wait", "type": "wait", "status": "%s"},
+   {"id": "s2", "refId": "2", "requisiteStageRefIds": ["1"], "name": "Deploy", "type": "deploy", "status": "%s"}
+  ]
+ }
+]
+`
+
+const watchEvents = `
+{"time":"2019-02-20T18:16:40Z","event":"stage.started","executionId":"someId","stageId":"s1","refId":"1","name":"Wait","type":"wait","status":"RUNNING"}
+{"time":"2019-02-20T18:16:40Z","event":"stage.succeeded","executionId":"someId","stageId":"s1","refId":"1","name":"Wait","type":"wait","status":"SUCCEEDED"}
+{"time":"2019-02-20T18:16:40Z","event":"stage.started","executionId":"someId","stageId":"s2","refId":"2","name":"Deploy","type":"deploy","status":"RUNNING"}
+{"time":"2019-02-20T18:16:40Z","event":"stage.succeeded","executionId":"someId","stageId":"s2","refId":"2","name":"Deploy","type":"deploy","status":"SUCCEEDED"}
+{"time":"2019-02-20T18:16:40Z","event":"execution.completed","executionId":"someId","name":"deploy","status":"SUCCEEDED"}
+`
@@ -20,6 +20,7 @@ import (
 	"net/http"
 
 	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
 )
 
 type getOptions struct {
@@ -54,6 +55,9 @@ func NewGetCmd(pipelineOptions *PipelineOptions) *cobra.Command {
 }
 
 func getPipeline(cmd *cobra.Command, options *getOptions) error {
+	if options.application == "" {
+		options.application = options.GateClient.DefaultApplication()
+	}
 	if options.application == "" || options.name == "" {
 		return errors.New("one of required parameters 'application' or 'name' not set")
 	}
@@ -62,15 +66,15 @@ func getPipeline(cmd *cobra.Command, options *getOptions) error {
 		options.application,
 		options.name)
 
-	if err != nil {
-		return err
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("Encountered an error getting pipeline in pipeline %s with name %s, status code: %d\n",
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Encountered an error getting pipeline in pipeline %s with name %s, %v\n",
 			options.application,
 			options.name,
-			resp.StatusCode)
+			gateclient.ResponseError(resp, err))
+	}
+
+	if err != nil {
+		return err
 	}
 
 	options.Ui.JsonOutput(successPayload)
@@ -138,6 +138,26 @@ func TestPipelineGet_notfound(t *testing.T) {
 	}
 }
 
+func TestPipelineGet_gateError(t *testing.T) {
+	ts := testGatePipelineGetError()
+	defer ts.Close()
+
+	rootCmd, rootOpts := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	pipelineCmd, _ := NewPipelineCmd(rootOpts)
+	rootCmd.AddCommand(pipelineCmd)
+
+	args := []string{"pipeline", "get", "--application", "app", "--name", "one", "--gate-endpoint", ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Expected failure but command succeeded")
+	}
+	expected := "status code: 403: Access denied to application app"
+	if !strings.Contains(err.Error(), expected) {
+		t.Fatalf("Expected error to contain %q, got: %s", expected, err)
+	}
+}
+
 // testGatePipelineGetSuccess spins up a local http server that we will configure the GateClient
 // to direct requests to. Responds with a 200 and a well-formed pipeline get response.
 func testGatePipelineGetSuccess() *httptest.Server {
@@ -166,6 +186,17 @@ func testGatePipelineGetMissing() *httptest.Server {
 	return httptest.NewServer(mux)
 }
 
+// testGatePipelineGetError returns a 403 with a Spring error body.
+func testGatePipelineGetError() *httptest.Server {
+	mux := util.TestGateMuxWithVersionHandler()
+	mux.Handle("/applications/app/pipelineConfigs/one", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprintln(w, `{"error": "Forbidden", "message": "Access denied to application app", "status": 403}`)
+	}))
+	return httptest.NewServer(mux)
+}
+
 const malformedPipelineGetJson = `
   "application": "app",
   "id": "pipeline_one",
@@ -0,0 +1,249 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+)
+
+type historyOptions struct {
+	*PipelineOptions
+	application string
+	name        string
+	limit       int32
+}
+
+type historyShowOptions struct {
+	*historyOptions
+	revision int
+}
+
+type historyDiffOptions struct {
+	*historyOptions
+	from       int
+	to         int
+	structural bool
+}
+
+var (
+	historyPipelineShort = "List the saved revisions of the provided pipeline"
+	historyPipelineLong  = "List the saved revisions of the provided pipeline, newest first. Revision 0 is the current pipeline config."
+
+	historyShowPipelineShort = "Show a saved revision of the provided pipeline"
+	historyShowPipelineLong  = "Show a saved revision of the provided pipeline"
+
+	historyDiffPipelineShort = "Diff two saved revisions of the provided pipeline"
+	historyDiffPipelineLong  = "Diff two saved revisions of the provided pipeline"
+)
+
+func NewHistoryCmd(pipelineOptions *PipelineOptions) *cobra.Command {
+	options := &historyOptions{
+		PipelineOptions: pipelineOptions,
+	}
+	cmd := &cobra.Command{
+		Use:     "history",
+		Aliases: []string{},
+		Short:   historyPipelineShort,
+		Long:    historyPipelineLong,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listPipelineHistory(cmd, options)
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.application, "application", "a", "", "Spinnaker application the pipeline lives in")
+	cmd.PersistentFlags().StringVarP(&options.name, "name", "n", "", "name of the pipeline")
+	cmd.PersistentFlags().Int32VarP(&options.limit, "limit", "l", -1, "number of revisions to fetch")
+
+	// create subcommands
+	cmd.AddCommand(newHistoryShowCmd(options))
+	cmd.AddCommand(newHistoryDiffCmd(options))
+	return cmd
+}
+
+func newHistoryShowCmd(historyOptions *historyOptions) *cobra.Command {
+	options := &historyShowOptions{
+		historyOptions: historyOptions,
+	}
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: historyShowPipelineShort,
+		Long:  historyShowPipelineLong,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return showPipelineRevision(cmd, options)
+		},
+	}
+
+	cmd.PersistentFlags().IntVarP(&options.revision, "revision", "r", 0, "revision to show")
+
+	return cmd
+}
+
+func newHistoryDiffCmd(historyOptions *historyOptions) *cobra.Command {
+	options := &historyDiffOptions{
+		historyOptions: historyOptions,
+	}
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: historyDiffPipelineShort,
+		Long:  historyDiffPipelineLong,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return diffPipelineRevisions(cmd, options)
+		},
+	}
+
+	cmd.PersistentFlags().IntVar(&options.from, "from", 1, "revision to diff from")
+	cmd.PersistentFlags().IntVar(&options.to, "to", 0, "revision to diff to")
+	cmd.PersistentFlags().BoolVar(&options.structural, "structural", false, "list changed JSON paths instead of a unified diff")
+
+	return cmd
+}
+
+func listPipelineHistory(cmd *cobra.Command, options *historyOptions) error {
+	history, err := getPipelineHistory(options)
+	if err != nil {
+		return err
+	}
+
+	revisions := make([]map[string]interface{}, 0, len(history))
+	for i, revision := range history {
+		revisions = append(revisions, map[string]interface{}{
+			"revision":       i,
+			"timestamp":      revisionTimestamp(revision),
+			"lastModifiedBy": revision["lastModifiedBy"],
+			"index":          revision["index"],
+		})
+	}
+
+	options.Ui.JsonOutput(revisions)
+	return nil
+}
+
+func showPipelineRevision(cmd *cobra.Command, options *historyShowOptions) error {
+	history, err := getPipelineHistory(options.historyOptions)
+	if err != nil {
+		return err
+	}
+	revision, err := pipelineRevision(history, options.revision)
+	if err != nil {
+		return err
+	}
+
+	options.Ui.JsonOutput(revision)
+	return nil
+}
+
+func diffPipelineRevisions(cmd *cobra.Command, options *historyDiffOptions) error {
+	history, err := getPipelineHistory(options.historyOptions)
+	if err != nil {
+		return err
+	}
+	from, err := pipelineRevision(history, options.from)
+	if err != nil {
+		return err
+	}
+	to, err := pipelineRevision(history, options.to)
+	if err != nil {
+		return err
+	}
+
+	changes, err := pipelineDiff(
+		fmt.Sprintf("revision %d", options.from), withoutServerManagedFields(from),
+		fmt.Sprintf("revision %d", options.to), withoutServerManagedFields(to),
+		options.structural)
+	if err != nil {
+		return err
+	}
+
+	if changes == "" {
+		options.Ui.Info(fmt.Sprintf("Revisions %d and %d are identical", options.from, options.to))
+		return nil
+	}
+	options.Ui.DiffOutput(changes)
+	return nil
+}
+
+// getPipelineHistory returns the saved revisions of the pipeline, newest first.
+func getPipelineHistory(options *historyOptions) ([]map[string]interface{}, error) {
+	if options.application == "" {
+		options.application = options.GateClient.DefaultApplication()
+	}
+	if options.application == "" || options.name == "" {
+		return nil, errors.New("one of required parameters 'application' or 'name' not set")
+	}
+
+	foundPipeline, err := getExistingPipeline(options.PipelineOptions, options.application, options.name)
+	if err != nil {
+		return nil, err
+	}
+	id, _ := foundPipeline["id"].(string)
+	if id == "" {
+		return nil, fmt.Errorf("Pipeline %s not found in application %s\n", options.name, options.application)
+	}
+
+	query := map[string]interface{}{}
+	if options.limit > 0 {
+		query["limit"] = options.limit
+	}
+	payload, resp, err := options.GateClient.PipelineConfigControllerApi.GetPipelineConfigHistoryUsingGET(options.GateClient.Context, id, query)
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Encountered an error getting history of pipeline %s, %v\n",
+			options.name,
+			gateclient.ResponseError(resp, err))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	history := make([]map[string]interface{}, 0, len(payload))
+	for _, p := range payload {
+		if revision, ok := p.(map[string]interface{}); ok {
+			history = append(history, revision)
+		}
+	}
+	return history, nil
+}
+
+func pipelineRevision(history []map[string]interface{}, revision int) (map[string]interface{}, error) {
+	if revision < 0 || revision >= len(history) {
+		return nil, fmt.Errorf("Revision %d not found, pipeline has %d revisions\n", revision, len(history))
+	}
+	return history[revision], nil
+}
+
+// revisionTimestamp formats the revision's updateTs, which Front50 stores as
+// milliseconds since the epoch.
+func revisionTimestamp(revision map[string]interface{}) string {
+	var millis int64
+	switch ts := revision["updateTs"].(type) {
+	case string:
+		parsed, err := strconv.ParseInt(ts, 10, 64)
+		if err != nil {
+			return ts
+		}
+		millis = parsed
+	case float64:
+		millis = int64(ts)
+	default:
+		return ""
+	}
+	return time.Unix(0, millis*int64(time.Millisecond)).UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,203 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package pipeline
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andreyvit/diff"
+	"github.com/spinnaker/spin/cmd"
+	"github.com/spinnaker/spin/util"
+)
+
+func TestPipelineHistory_list(t *testing.T) {
+	ts := testGatePipelineHistorySuccess(new(bytes.Buffer))
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, rootOpts := cmd.NewCmdRoot(buffer, buffer)
+	pipelineCmd, _ := NewPipelineCmd(rootOpts)
+	rootCmd.AddCommand(pipelineCmd)
+
+	args := []string{"pipeline", "history", "--application", "app", "--name", "one", "--gate-endpoint", ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	expected := strings.TrimSpace(pipelineHistoryListJson)
+	recieved := strings.TrimSpace(buffer.String())
+	if expected != recieved {
+		t.Fatalf("Unexpected command output:\n%s", diff.LineDiff(expected, recieved))
+	}
+}
+
+func TestPipelineHistory_show(t *testing.T) {
+	ts := testGatePipelineHistorySuccess(new(bytes.Buffer))
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, rootOpts := cmd.NewCmdRoot(buffer, buffer)
+	pipelineCmd, _ := NewPipelineCmd(rootOpts)
+	rootCmd.AddCommand(pipelineCmd)
+
+	args := []string{"pipeline", "history", "show", "--application", "app", "--name", "one", "--revision", "1", "--gate-endpoint", ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+	if !strings.Contains(buffer.String(), `"waitTime": 30`) {
+		t.Fatalf("Unexpected command output:\n%s", buffer.String())
+	}
+}
+
+func TestPipelineHistory_diff(t *testing.T) {
+	ts := testGatePipelineHistorySuccess(new(bytes.Buffer))
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, rootOpts := cmd.NewCmdRoot(buffer, buffer)
+	pipelineCmd, _ := NewPipelineCmd(rootOpts)
+	rootCmd.AddCommand(pipelineCmd)
+
+	args := []string{"pipeline", "history", "diff", "--application", "app", "--name", "one", "--structural", "--no-color=false", "--gate-endpoint", ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	expected := strings.TrimSpace(pipelineHistoryDiff)
+	recieved := strings.TrimSpace(buffer.String())
+	if expected != recieved {
+		t.Fatalf("Unexpected command output:\n%s", diff.LineDiff(expected, recieved))
+	}
+}
+
+func TestPipelineHistory_badrevision(t *testing.T) {
+	ts := testGatePipelineHistorySuccess(new(bytes.Buffer))
+	defer ts.Close()
+
+	rootCmd, rootOpts := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	pipelineCmd, _ := NewPipelineCmd(rootOpts)
+	rootCmd.AddCommand(pipelineCmd)
+
+	args := []string{"pipeline", "history", "show", "--application", "app", "--name", "one", "--revision", "5", "--gate-endpoint", ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+}
+
+func TestPipelineHistory_flags(t *testing.T) {
+	ts := testGateSuccess()
+	defer ts.Close()
+
+	rootCmd, rootOpts := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	pipelineCmd, _ := NewPipelineCmd(rootOpts)
+	rootCmd.AddCommand(pipelineCmd)
+
+	args := []string{"pipeline", "history", "--gate-endpoint", ts.URL} // Missing application and name.
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+}
+
+func TestPipelineHistory_fail(t *testing.T) {
+	ts := testGateFail()
+	defer ts.Close()
+
+	rootCmd, rootOpts := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	pipelineCmd, _ := NewPipelineCmd(rootOpts)
+	rootCmd.AddCommand(pipelineCmd)
+
+	args := []string{"pipeline", "history", "--application", "app", "--name", "one", "--gate-endpoint", ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+}
+
+// testGatePipelineHistorySuccess spins up a local http server that we will configure
+// the GateClient to direct requests to. Responds with the current pipeline and its
+// history. Writes saved pipeline bodies to buffer for testing.
+func testGatePipelineHistorySuccess(buffer *bytes.Buffer) *httptest.Server {
+	mux := util.TestGateMuxWithVersionHandler()
+	mux.Handle("/applications/app/pipelineConfigs/one", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"id": "id1", "application": "app", "name": "one", "index": 3}`)
+	}))
+	mux.Handle("/pipelineConfigs/id1/history", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, strings.TrimSpace(pipelineHistoryJson))
+	}))
+	mux.Handle("/pipelines", util.NewTestBufferHandlerFunc(http.MethodPost, buffer, http.StatusOK, ""))
+	return httptest.NewServer(mux)
+}
+
+const pipelineHistoryJson = `
+[
+  {
+    "application": "app",
+    "id": "id1",
+    "index": 3,
+    "lastModifiedBy": "bob",
+    "name": "one",
+    "stages": [{"name": "Wait", "refId": "1", "type": "wait", "waitTime": 60}],
+    "updateTs": "1577836800000"
+  },
+  {
+    "application": "app",
+    "id": "id1",
+    "index": 0,
+    "lastModifiedBy": "alice",
+    "name": "one",
+    "stages": [{"name": "Wait", "refId": "1", "type": "wait", "waitTime": 30}],
+    "updateTs": "1577750400000"
+  }
+]
+`
+
+const pipelineHistoryListJson = `
+[
+ {
+  "index": 3,
+  "lastModifiedBy": "bob",
+  "revision": 0,
+  "timestamp": "2020-01-01T00:00:00Z"
+ },
+ {
+  "index": 0,
+  "lastModifiedBy": "alice",
+  "revision": 1,
+  "timestamp": "2019-12-31T00:00:00Z"
+ }
+]
+`
+
+const pipelineHistoryDiff = `
+- stages[0].waitTime: 30
++ stages[0].waitTime: 60
+`
@@ -20,6 +20,8 @@ import (
 	"net/http"
 
 	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+	"github.com/spinnaker/spin/cmd/output"
 )
 
 type listOptions struct {
@@ -33,6 +35,15 @@ var (
 	listPipelineLong  = "List the pipelines for the provided application"
 )
 
+// pipelineColumns are the columns of table output.
+var pipelineColumns = []output.Column{
+	{Header: "NAME", Path: "{.name}"},
+	{Header: "ID", Path: "{.id}"},
+	{Header: "TRIGGERS", Path: "{.triggers[*].type}", Wide: true},
+	{Header: "LAST MODIFIED BY", Path: "{.lastModifiedBy}", Wide: true},
+	{Header: "UPDATED", Path: "{.updateTs}", Wide: true, Timestamp: true},
+}
+
 func NewListCmd(pipelineOptions *PipelineOptions) *cobra.Command {
 	options := &listOptions{
 		PipelineOptions: pipelineOptions,
@@ -53,22 +64,25 @@ func NewListCmd(pipelineOptions *PipelineOptions) *cobra.Command {
 }
 
 func listPipeline(cmd *cobra.Command, options *listOptions) error {
+	if options.application == "" {
+		options.application = options.GateClient.DefaultApplication()
+	}
 	if options.application == "" {
 		return errors.New("required parameter 'application' not set")
 	}
 
 	successPayload, resp, err := options.GateClient.ApplicationControllerApi.GetPipelineConfigsForApplicationUsingGET(options.GateClient.Context, options.application)
 
-	if err != nil {
-		return err
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Encountered an error listing pipelines for application %s, %v\n",
+			options.application,
+			gateclient.ResponseError(resp, err))
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("Encountered an error listing pipelines for application %s, status code: %d\n",
-			options.application,
-			resp.StatusCode)
+	if err != nil {
+		return err
 	}
 
-	options.Ui.JsonOutput(successPayload)
+	options.Ui.TableOutput(successPayload, pipelineColumns)
 	return nil
 }
@@ -19,6 +19,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"strings"
 	"testing"
 
@@ -90,6 +91,55 @@ func TestPipelineList_fail(t *testing.T) {
 	}
 }
 
+func TestPipelineList_context(t *testing.T) {
+	mux := util.TestGateMuxWithVersionHandler()
+	mux.Handle("/applications/ctxapp/pipelineConfigs", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, strings.TrimSpace(pipelineListJson))
+	}))
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	tempFile := tempPipelineFile(fmt.Sprintf(contextConfig, ts.URL))
+	if tempFile == nil {
+		t.Fatal("Could not create temp config file.")
+	}
+	defer os.Remove(tempFile.Name())
+
+	rootCmd, rootOpts := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	pipelineCmd, _ := NewPipelineCmd(rootOpts)
+	rootCmd.AddCommand(pipelineCmd)
+
+	// Gate endpoint and application both come from the 'staging' context.
+	args := []string{"pipeline", "list", "--config", tempFile.Name(), "--context", "staging"}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+}
+
+func TestPipelineList_missingcontext(t *testing.T) {
+	ts := testGatePipelineListSuccess()
+	defer ts.Close()
+
+	tempFile := tempPipelineFile(fmt.Sprintf(contextConfig, ts.URL))
+	if tempFile == nil {
+		t.Fatal("Could not create temp config file.")
+	}
+	defer os.Remove(tempFile.Name())
+
+	rootCmd, rootOpts := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	pipelineCmd, _ := NewPipelineCmd(rootOpts)
+	rootCmd.AddCommand(pipelineCmd)
+
+	args := []string{"pipeline", "list", "--application", "app", "--config", tempFile.Name(), "--context", "prod", "--gate-endpoint", ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+}
+
 // testGatePipelineListSuccess spins up a local http server that we will configure the GateClient
 // to direct requests to. Responds with a 200 and a well-formed pipeline list.
 func testGatePipelineListSuccess() *httptest.Server {
@@ -175,3 +225,15 @@ const pipelineListJson = `
   }
 ]
 `
+
+const contextConfig = `
+currentContext: dev
+contexts:
+  dev:
+    gate:
+      endpoint: http://localhost:1
+  staging:
+    gate:
+      endpoint: %s
+    defaultApplication: ctxapp
+`
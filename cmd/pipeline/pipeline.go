@@ -33,5 +33,9 @@ func NewPipelineCmd(rootOptions *cmd.RootOptions) (*cobra.Command, *PipelineOpti
 	cmd.AddCommand(NewDeleteCmd(options))
 	cmd.AddCommand(NewSaveCmd(options))
 	cmd.AddCommand(NewExecuteCmd(options))
+	cmd.AddCommand(NewApplyCmd(options))
+	cmd.AddCommand(NewDiffCmd(options))
+	cmd.AddCommand(NewHistoryCmd(options))
+	cmd.AddCommand(NewRollbackCmd(options))
 	return cmd, options
 }
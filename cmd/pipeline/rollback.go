@@ -0,0 +1,94 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package pipeline
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+)
+
+type rollbackOptions struct {
+	*historyOptions
+	revision int
+}
+
+var (
+	rollbackPipelineShort = "Roll back the provided pipeline to a saved revision"
+	rollbackPipelineLong  = "Save a previous revision of the provided pipeline as its current config. Revision 1 is the revision before the current one."
+)
+
+func NewRollbackCmd(pipelineOptions *PipelineOptions) *cobra.Command {
+	options := &rollbackOptions{
+		historyOptions: &historyOptions{
+			PipelineOptions: pipelineOptions,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "rollback",
+		Aliases: []string{},
+		Short:   rollbackPipelineShort,
+		Long:    rollbackPipelineLong,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return rollbackPipeline(cmd, options)
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.application, "application", "a", "", "Spinnaker application the pipeline lives in")
+	cmd.PersistentFlags().StringVarP(&options.name, "name", "n", "", "name of the pipeline to roll back")
+	cmd.PersistentFlags().IntVarP(&options.revision, "revision", "r", 1, "revision to roll back to")
+	cmd.PersistentFlags().Int32VarP(&options.limit, "limit", "l", -1, "number of revisions to fetch")
+
+	return cmd
+}
+
+func rollbackPipeline(cmd *cobra.Command, options *rollbackOptions) error {
+	history, err := getPipelineHistory(options.historyOptions)
+	if err != nil {
+		return err
+	}
+	revision, err := pipelineRevision(history, options.revision)
+	if err != nil {
+		return err
+	}
+
+	// The current revision is re-fetched rather than taken from the history
+	// so that the pipeline keeps its current id and position.
+	current, err := getExistingPipeline(options.PipelineOptions, options.application, options.name)
+	if err != nil {
+		return err
+	}
+	pipelineJson := withoutServerManagedFields(revision)
+	pipelineJson["id"] = current["id"]
+	if index, exists := current["index"]; exists {
+		pipelineJson["index"] = index
+	}
+
+	saveResp, err := options.GateClient.PipelineControllerApi.SavePipelineUsingPOST(options.GateClient.Context, pipelineJson)
+	if saveResp != nil && saveResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Encountered an error saving pipeline, %v\n", gateclient.ResponseError(saveResp, err))
+	}
+	if err != nil {
+		return err
+	}
+
+	options.Ui.Success(fmt.Sprintf("Pipeline %s rolled back to revision %d from %s",
+		options.name,
+		options.revision,
+		revisionTimestamp(revision)))
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package pipeline
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/spinnaker/spin/cmd"
+	"github.com/spinnaker/spin/util"
+)
+
+func TestPipelineRollback_basic(t *testing.T) {
+	saveBuffer := new(bytes.Buffer)
+	ts := testGatePipelineHistorySuccess(saveBuffer)
+	defer ts.Close()
+
+	rootCmd, rootOpts := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	pipelineCmd, _ := NewPipelineCmd(rootOpts)
+	rootCmd.AddCommand(pipelineCmd)
+
+	args := []string{"pipeline", "rollback", "--application", "app", "--name", "one", "--revision", "1", "--gate-endpoint", ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	// Revision 1 is saved with the current id and index.
+	expected := `
+{
+ "application": "app",
+ "id": "id1",
+ "index": 3,
+ "name": "one",
+ "stages": [
+  {
+   "name": "Wait",
+   "refId": "1",
+   "type": "wait",
+   "waitTime": 30
+  }
+ ]
+}`
+	util.TestPrettyJsonDiff(t, "save request body", expected[1:], saveBuffer.Bytes())
+}
+
+func TestPipelineRollback_badrevision(t *testing.T) {
+	saveBuffer := new(bytes.Buffer)
+	ts := testGatePipelineHistorySuccess(saveBuffer)
+	defer ts.Close()
+
+	rootCmd, rootOpts := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	pipelineCmd, _ := NewPipelineCmd(rootOpts)
+	rootCmd.AddCommand(pipelineCmd)
+
+	args := []string{"pipeline", "rollback", "--application", "app", "--name", "one", "--revision", "2", "--gate-endpoint", ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+	if saveBuffer.Len() != 0 {
+		t.Fatalf("Pipeline saved despite missing revision: %s", saveBuffer.String())
+	}
+}
+
+func TestPipelineRollback_fail(t *testing.T) {
+	ts := testGateFail()
+	defer ts.Close()
+
+	rootCmd, rootOpts := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	pipelineCmd, _ := NewPipelineCmd(rootOpts)
+	rootCmd.AddCommand(pipelineCmd)
+
+	args := []string{"pipeline", "rollback", "--application", "app", "--name", "one", "--gate-endpoint", ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+}
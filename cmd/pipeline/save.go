@@ -19,6 +19,7 @@ import (
 	"net/http"
 
 	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
 
 	"github.com/spinnaker/spin/util"
 )
@@ -58,6 +59,43 @@ func savePipeline(cmd *cobra.Command, options *saveOptions) error {
 	if err != nil {
 		return err
 	}
+	if err := validatePipeline(options.PipelineOptions, pipelineJson); err != nil {
+		return err
+	}
+	application := pipelineJson["application"].(string)
+	pipelineName := pipelineJson["name"].(string)
+
+	foundPipeline, err := getExistingPipeline(options.PipelineOptions, application, pipelineName)
+	if err != nil {
+		return err
+	}
+
+	_, exists := pipelineJson["id"].(string)
+	var foundPipelineId string
+	if len(foundPipeline) > 0 {
+		foundPipelineId = foundPipeline["id"].(string)
+	}
+	if !exists && foundPipelineId != "" {
+		pipelineJson["id"] = foundPipelineId
+	}
+
+	saveResp, saveErr := options.GateClient.PipelineControllerApi.SavePipelineUsingPOST(options.GateClient.Context, pipelineJson)
+
+	if saveResp != nil && saveResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Encountered an error saving pipeline, %v\n", gateclient.ResponseError(saveResp, saveErr))
+	}
+	if saveErr != nil {
+		return saveErr
+	}
+
+	options.Ui.Success("Pipeline save succeeded")
+	return nil
+}
+
+// validatePipeline checks that the pipeline has the keys required to save it,
+// reporting each problem through the Ui. Templated pipelines are marked with
+// the 'templatedPipeline' type.
+func validatePipeline(options *PipelineOptions, pipelineJson map[string]interface{}) error {
 	valid := true
 	if _, exists := pipelineJson["name"]; !exists {
 		options.Ui.Error("Required pipeline key 'name' missing...\n")
@@ -80,33 +118,21 @@ func savePipeline(cmd *cobra.Command, options *saveOptions) error {
 	if !valid {
 		return fmt.Errorf("Submitted pipeline is invalid: %s\n", pipelineJson)
 	}
-	application := pipelineJson["application"].(string)
-	pipelineName := pipelineJson["name"].(string)
-
-	foundPipeline, queryResp, _ := options.GateClient.ApplicationControllerApi.GetPipelineConfigUsingGET(options.GateClient.Context, application, pipelineName)
-
-	if queryResp.StatusCode != http.StatusOK {
-		return fmt.Errorf("Encountered an error querying pipeline, status code: %d\n", queryResp.StatusCode)
-	}
+	return nil
+}
 
-	_, exists := pipelineJson["id"].(string)
-	var foundPipelineId string
-	if len(foundPipeline) > 0 {
-		foundPipelineId = foundPipeline["id"].(string)
-	}
-	if !exists && foundPipelineId != "" {
-		pipelineJson["id"] = foundPipelineId
+// getExistingPipeline returns the saved config of the named pipeline, or an
+// empty map if no such pipeline exists.
+func getExistingPipeline(options *PipelineOptions, application, name string) (map[string]interface{}, error) {
+	foundPipeline, queryResp, err := options.GateClient.ApplicationControllerApi.GetPipelineConfigUsingGET(options.GateClient.Context, application, name)
+	if queryResp == nil {
+		return nil, err
 	}
 
-	saveResp, saveErr := options.GateClient.PipelineControllerApi.SavePipelineUsingPOST(options.GateClient.Context, pipelineJson)
-
-	if saveErr != nil {
-		return saveErr
-	}
-	if saveResp.StatusCode != http.StatusOK {
-		return fmt.Errorf("Encountered an error saving pipeline, status code: %d\n", saveResp.StatusCode)
+	// Gate responds with an empty body for pipelines that don't exist, so
+	// decoding errors on a successful response are expected.
+	if queryResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Encountered an error querying pipeline, %v\n", gateclient.ResponseError(queryResp, err))
 	}
-
-	options.Ui.Success("Pipeline save succeeded")
-	return nil
+	return foundPipeline, nil
 }
@@ -21,6 +21,7 @@ import (
 	"github.com/spinnaker/spin/util"
 
 	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
 )
 
 type getOptions struct {
@@ -64,7 +65,7 @@ func getProject(cmd *cobra.Command, options *getOptions, args []string) error {
 		if resp.StatusCode == http.StatusNotFound {
 			return fmt.Errorf("Project '%s' not found\n", projectName)
 		} else if resp.StatusCode != http.StatusOK {
-			return fmt.Errorf("Encountered an error getting project, status code: %d\n", resp.StatusCode)
+			return fmt.Errorf("Encountered an error getting project, %v\n", gateclient.ResponseError(resp, err))
 		}
 	}
 
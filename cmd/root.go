@@ -1,7 +1,14 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -10,14 +17,38 @@ import (
 	"github.com/spinnaker/spin/version"
 )
 
+// LocalOnlyAnnotation marks commands that only operate on local state, such as
+// the spin config file. No Gate client is created for these commands or their
+// subcommands.
+const LocalOnlyAnnotation = "spin/local-only"
+
+// Exit codes of spin. Commands aborted by --timeout or by SIGINT/SIGTERM exit
+// with the same codes as timeout(1) and shells, respectively.
+const (
+	ExitCodeError       = 1
+	ExitCodeTimeout     = 124
+	ExitCodeInterrupted = 130
+)
+
 type RootOptions struct {
 	configPath       string
+	contextName      string
 	gateEndpoint     string
 	ignoreCertErrors bool
 	quiet            bool
 	color            bool
 	outputFormat     string
+	sortBy           string
+	noHeaders        bool
 	defaultHeaders   string
+	retryAttempts    int
+	retryPost        bool
+	timeout          time.Duration
+	verbose          int
+
+	// ctx is canceled when the timeout elapses or spin is interrupted.
+	ctx    context.Context
+	cancel context.CancelFunc
 
 	Ui         output.Ui
 	GateClient *gateclient.GatewayClient
@@ -38,14 +69,21 @@ func NewCmdRoot(outWriter, errWriter io.Writer) (*cobra.Command, *RootOptions) {
 
 	// GateClient Flags
 	cmd.PersistentFlags().StringVar(&options.configPath, "config", "", "path to config file (default $HOME/.spin/config)")
+	cmd.PersistentFlags().StringVar(&options.contextName, "context", "", "name of the config context to use (default is the config's currentContext)")
 	cmd.PersistentFlags().StringVar(&options.gateEndpoint, "gate-endpoint", "", "Gate (API server) endpoint (default http://localhost:8084)")
 	cmd.PersistentFlags().BoolVarP(&options.ignoreCertErrors, "insecure", "k", false, "ignore certificate errors")
 	cmd.PersistentFlags().StringVar(&options.defaultHeaders, "default-headers", "", "configure default headers for gate client as comma separated list (e.g. key1=value1,key2=value2)")
+	cmd.PersistentFlags().IntVar(&options.retryAttempts, "retry-attempts", 0, "maximum attempts for requests failing with transient errors, 1 disables retries (default 3)")
+	cmd.PersistentFlags().BoolVar(&options.retryPost, "retry-post", false, "also retry POST requests, which may not be idempotent")
+	cmd.PersistentFlags().DurationVar(&options.timeout, "timeout", 0, "maximum time for the command to complete, e.g. 30s or 10m (0 means no timeout)")
 
 	// UI Flags
 	cmd.PersistentFlags().BoolVarP(&options.quiet, "quiet", "q", false, "squelch non-essential output")
+	cmd.PersistentFlags().CountVarP(&options.verbose, "verbose", "v", "log Gate requests to stderr, -vv also logs headers and bodies with credentials redacted")
 	cmd.PersistentFlags().BoolVar(&options.color, "no-color", true, "disable color")
 	cmd.PersistentFlags().StringVarP(&options.outputFormat, "output", "o", "", "configure output formatting")
+	cmd.PersistentFlags().StringVar(&options.sortBy, "sort-by", "", "sort table output by a column name or jsonpath expression")
+	cmd.PersistentFlags().BoolVar(&options.noHeaders, "no-headers", false, "omit column headers from table output")
 
 	// Initialize UI & GateClient
 	outw := outWriter
@@ -55,15 +93,20 @@ func NewCmdRoot(outWriter, errWriter io.Writer) (*cobra.Command, *RootOptions) {
 		if err != nil {
 			return err
 		}
-		options.Ui = output.NewUI(options.quiet, options.color, outputFormater, outw, errw)
-
-		gateClient, err := gateclient.NewGateClient(
-			options.Ui,
-			options.gateEndpoint,
-			options.defaultHeaders,
-			options.configPath,
-			options.ignoreCertErrors,
-		)
+		ui := output.NewUI(options.quiet, options.color, outputFormater, cmd.InOrStdin(), outw, errw)
+		if tableFormat := output.ParseTableFormat(options.outputFormat); tableFormat != nil {
+			tableFormat.SortBy = options.sortBy
+			tableFormat.NoHeaders = options.noHeaders
+			ui.TableFormat = tableFormat
+		}
+		options.Ui = ui
+		options.ctx, options.cancel = options.newContext()
+
+		if isLocalOnly(cmd) {
+			return nil
+		}
+
+		gateClient, err := options.NewGateClient()
 		if err != nil {
 			return err
 		}
@@ -74,3 +117,106 @@ func NewCmdRoot(outWriter, errWriter io.Writer) (*cobra.Command, *RootOptions) {
 
 	return cmd, options
 }
+
+// NewGateClient creates a Gate client from the global flags, authenticating
+// with the configured method.
+func (o *RootOptions) NewGateClient() (*gateclient.GatewayClient, error) {
+	return gateclient.NewGateClient(
+		o.Context(),
+		o.Ui,
+		o.gateEndpoint,
+		o.defaultHeaders,
+		o.configPath,
+		o.contextName,
+		o.ignoreCertErrors,
+		o.retryAttempts,
+		o.retryPost,
+		o.verbose,
+	)
+}
+
+// newContext returns a context canceled when the --timeout elapses or on
+// SIGINT/SIGTERM. After the first signal, signals are no longer caught so that
+// a second one kills spin immediately. The returned cancel func must be called
+// once the command has finished, see Cancel.
+func (o *RootOptions) newContext() (context.Context, context.CancelFunc) {
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if o.timeout > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), o.timeout)
+	} else {
+		ctx, cancel = context.WithCancel(context.Background())
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		select {
+		case <-signals:
+			cancel()
+		case <-ctx.Done():
+		}
+		signal.Stop(signals)
+	}()
+	return ctx, cancel
+}
+
+// Cancel releases the context of the command. It must be called once the
+// command has finished and its exit status has been determined.
+func (o *RootOptions) Cancel() {
+	if o.cancel != nil {
+		o.cancel()
+	}
+}
+
+// Context returns the context of the running command, which is canceled when
+// the command times out or is interrupted.
+func (o *RootOptions) Context() context.Context {
+	if o.ctx == nil {
+		return context.Background()
+	}
+	return o.ctx
+}
+
+// ExitStatus returns the exit code and error to report for an error returned
+// by the command, replacing errors caused by the command being aborted. Errors
+// wrapping a context error of their own, such as an elapsed --wait-timeout,
+// keep their message but exit with the matching code.
+func (o *RootOptions) ExitStatus(err error) (int, error) {
+	switch {
+	case o.Context().Err() == context.DeadlineExceeded:
+		return ExitCodeTimeout, fmt.Errorf("Timed out after %s", o.timeout)
+	case o.Context().Err() == context.Canceled:
+		return ExitCodeInterrupted, errors.New("Interrupted")
+	case errors.Is(err, context.DeadlineExceeded):
+		return ExitCodeTimeout, err
+	case errors.Is(err, context.Canceled):
+		return ExitCodeInterrupted, err
+	default:
+		return ExitCodeError, err
+	}
+}
+
+// ConfigPath returns the config file location given by the --config flag.
+func (o *RootOptions) ConfigPath() string {
+	return o.configPath
+}
+
+// ContextName returns the config context given by the --context flag.
+func (o *RootOptions) ContextName() string {
+	return o.contextName
+}
+
+// Quiet reports whether non-essential output was squelched with --quiet.
+func (o *RootOptions) Quiet() bool {
+	return o.quiet
+}
+
+func isLocalOnly(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if _, ok := c.Annotations[LocalOnlyAnnotation]; ok {
+			return true
+		}
+	}
+	return false
+}
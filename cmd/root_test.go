@@ -0,0 +1,125 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestExitStatus_timeout(t *testing.T) {
+	rootCmd, rootOpts := NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(testBlockingCmd(rootOpts, nil))
+
+	rootCmd.SetArgs([]string{"block", "--timeout", "10ms"})
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Expected the command to time out")
+	}
+
+	code, err := rootOpts.ExitStatus(err)
+	if code != ExitCodeTimeout || err.Error() != "Timed out after 10ms" {
+		t.Fatalf("Unexpected exit status %d: %v", code, err)
+	}
+}
+
+func TestExitStatus_interrupted(t *testing.T) {
+	rootCmd, rootOpts := NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(testBlockingCmd(rootOpts, func() {
+		syscall.Kill(os.Getpid(), syscall.SIGINT)
+	}))
+
+	rootCmd.SetArgs([]string{"block"})
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Expected the command to be interrupted")
+	}
+
+	code, err := rootOpts.ExitStatus(err)
+	if code != ExitCodeInterrupted || err.Error() != "Interrupted" {
+		t.Fatalf("Unexpected exit status %d: %v", code, err)
+	}
+}
+
+func TestExitStatus_error(t *testing.T) {
+	rootCmd, rootOpts := NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(&cobra.Command{
+		Use:         "fail",
+		Annotations: map[string]string{LocalOnlyAnnotation: ""},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return os.ErrNotExist
+		},
+	})
+
+	rootCmd.SetArgs([]string{"fail", "--timeout", "1m"})
+	err := rootCmd.Execute()
+	code, err := rootOpts.ExitStatus(err)
+	if code != ExitCodeError || err != os.ErrNotExist {
+		t.Fatalf("Unexpected exit status %d: %v", code, err)
+	}
+}
+
+func TestExitStatus_wrappedTimeout(t *testing.T) {
+	rootCmd, rootOpts := NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(&cobra.Command{
+		Use:         "wait",
+		Annotations: map[string]string{LocalOnlyAnnotation: ""},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return fmt.Errorf("Timed out waiting: %w", context.DeadlineExceeded)
+		},
+	})
+
+	rootCmd.SetArgs([]string{"wait"})
+	err := rootCmd.Execute()
+	code, err := rootOpts.ExitStatus(err)
+	if code != ExitCodeTimeout || err.Error() != "Timed out waiting: context deadline exceeded" {
+		t.Fatalf("Unexpected exit status %d: %v", code, err)
+	}
+}
+
+func TestCancel(t *testing.T) {
+	rootCmd, rootOpts := NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(testBlockingCmd(rootOpts, func() {
+		rootOpts.Cancel()
+	}))
+
+	rootCmd.SetArgs([]string{"block"})
+	rootCmd.Execute()
+	if rootOpts.Context().Err() != context.Canceled {
+		t.Fatalf("Expected Cancel to cancel the command context")
+	}
+}
+
+// testBlockingCmd returns a local command that runs start, then blocks until
+// its context is canceled.
+func testBlockingCmd(options *RootOptions, start func()) *cobra.Command {
+	return &cobra.Command{
+		Use:         "block",
+		Annotations: map[string]string{LocalOnlyAnnotation: ""},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if start != nil {
+				start()
+			}
+			<-options.Context().Done()
+			return options.Context().Err()
+		},
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package server_group
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+	"github.com/spinnaker/spin/cmd/output"
+	"github.com/spinnaker/spin/util"
+)
+
+type activitiesOptions struct {
+	*serverGroupOptions
+	application string
+	account     string
+	region      string
+	provider    string
+}
+
+var (
+	activitiesServerGroupShort   = "List the scaling activities of a server group"
+	activitiesServerGroupLong    = "List the scaling activities, such as instance launches and terminations, of the specified server group"
+	activitiesServerGroupExample = "usage: spin server-group activities [options] server-group-name"
+)
+
+// activityColumns are the columns of table output.
+var activityColumns = []output.Column{
+	{Header: "START", Path: "{.startTime}", Timestamp: true},
+	{Header: "STATUS", Path: "{.statusCode}"},
+	{Header: "DESCRIPTION", Path: "{.description}"},
+	{Header: "END", Path: "{.endTime}", Wide: true, Timestamp: true},
+	{Header: "CAUSE", Path: "{.cause}", Wide: true},
+}
+
+// serverGroupVersion matches the version suffix of server group names, which
+// are named after their cluster, e.g. app-stack-v003.
+var serverGroupVersion = regexp.MustCompile(`-v\d{3,}$`)
+
+func NewActivitiesCmd(serverGroupOptions *serverGroupOptions) *cobra.Command {
+	options := &activitiesOptions{
+		serverGroupOptions: serverGroupOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "activities",
+		Short:   activitiesServerGroupShort,
+		Long:    activitiesServerGroupLong,
+		Example: activitiesServerGroupExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listActivities(cmd, options, args)
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.application, "application", "a", "", "Spinnaker application the server group belongs to")
+	cmd.PersistentFlags().StringVar(&options.account, "account", "", "account the server group is deployed to")
+	cmd.PersistentFlags().StringVar(&options.region, "region", "", "region of the server group")
+	cmd.PersistentFlags().StringVar(&options.provider, "provider", "aws", "cloud provider of the server group")
+
+	return cmd
+}
+
+func listActivities(cmd *cobra.Command, options *activitiesOptions, args []string) error {
+	name, err := util.ReadArgsOrStdin(args)
+	if err != nil {
+		return err
+	}
+	if options.application == "" {
+		options.application = options.GateClient.DefaultApplication()
+	}
+	if options.application == "" || options.account == "" || options.region == "" {
+		return errors.New("one of required parameters 'application', 'account' or 'region' not set")
+	}
+
+	activities, resp, err := options.GateClient.ClusterControllerApi.GetScalingActivitiesUsingGET(options.GateClient.Context,
+		options.account,
+		options.application,
+		clusterOf(name),
+		name,
+		map[string]interface{}{"provider": options.provider, "region": options.region})
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Encountered an error listing scaling activities of server group %s, %v\n",
+			name,
+			gateclient.ResponseError(resp, err))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	options.Ui.TableOutput(activities, activityColumns)
+	return nil
+}
+
+// clusterOf returns the name of the cluster of the server group.
+func clusterOf(serverGroupName string) string {
+	return serverGroupVersion.ReplaceAllString(serverGroupName, "")
+}
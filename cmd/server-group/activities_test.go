@@ -0,0 +1,114 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package server_group
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spinnaker/spin/cmd"
+	"github.com/spinnaker/spin/util"
+)
+
+func TestServerGroupActivities_table(t *testing.T) {
+	ts := testGateServerGroupActivitiesSuccess()
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, options := cmd.NewCmdRoot(buffer, buffer)
+	rootCmd.AddCommand(NewServerGroupCmd(options))
+
+	args := []string{"server-group", "activities", "-a", "app", "--account", "prod", "--region", "us-east-1", "app-main-v002", "-o", "table", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	expected := `START                  STATUS       DESCRIPTION
+2020-06-02T12:00:00Z   Successful   Launching a new EC2 instance: i-0123
+2020-06-02T13:00:00Z   InProgress   Terminating EC2 instance: i-0123`
+	recieved := strings.TrimSpace(buffer.String())
+	if expected != recieved {
+		t.Fatalf("Unexpected table output:\n%s", recieved)
+	}
+}
+
+func TestServerGroupActivities_fail(t *testing.T) {
+	ts := testGateFail()
+	defer ts.Close()
+
+	rootCmd, options := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewServerGroupCmd(options))
+
+	args := []string{"server-group", "activities", "-a", "app", "--account", "prod", "--region", "us-east-1", "app-main-v002", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Expected failure but command succeeded")
+	}
+}
+
+func TestClusterOf(t *testing.T) {
+	tests := map[string]string{
+		"app-main-v002":        "app-main",
+		"app-main-canary-v123": "app-main-canary",
+		"app-v1000":            "app",
+		"app-main":             "app-main",
+		"app-v2-frontend":      "app-v2-frontend",
+	}
+	for name, expected := range tests {
+		if cluster := clusterOf(name); cluster != expected {
+			t.Errorf("Expected cluster %q of %q, got %q", expected, name, cluster)
+		}
+	}
+}
+
+// testGateServerGroupActivitiesSuccess spins up a local http server that we will configure the GateClient
+// to direct requests to. Responds with the scaling activities of app-main-v002 in us-east-1.
+func testGateServerGroupActivitiesSuccess() *httptest.Server {
+	mux := util.TestGateMuxWithVersionHandler()
+	mux.Handle("/applications/app/clusters/prod/app-main/serverGroups/app-main-v002/scalingActivities", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("region") != "us-east-1" || r.URL.Query().Get("provider") != "aws" {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintln(w, strings.TrimSpace(activitiesJson))
+	}))
+	return httptest.NewServer(mux)
+}
+
+const activitiesJson = `
+[
+  {
+    "description": "Launching a new EC2 instance: i-0123",
+    "cause": "An instance was started in response to a difference between desired and actual capacity.",
+    "statusCode": "Successful",
+    "startTime": 1591099200000,
+    "endTime": 1591099260000
+  },
+  {
+    "description": "Terminating EC2 instance: i-0123",
+    "cause": "An instance was taken out of service in response to a user request.",
+    "statusCode": "InProgress",
+    "startTime": 1591102800000
+  }
+]
+`
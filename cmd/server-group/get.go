@@ -0,0 +1,107 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package server_group
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+	"github.com/spinnaker/spin/cmd/output"
+	"github.com/spinnaker/spin/util"
+)
+
+type getOptions struct {
+	*serverGroupOptions
+	application string
+	account     string
+	region      string
+}
+
+var (
+	getServerGroupShort   = "Get the specified server group"
+	getServerGroupLong    = "Get the details of the specified server group in an account and region"
+	getServerGroupExample = "usage: spin server-group get [options] server-group-name"
+)
+
+// serverGroupDetailColumns are the columns of table output.
+var serverGroupDetailColumns = []output.Column{
+	{Header: "NAME", Path: "{.name}"},
+	{Header: "REGION", Path: "{.region}"},
+	{Header: "DISABLED", Path: "{.disabled}"},
+	{Header: "INSTANCES", Path: "{.instanceCounts.total}"},
+	{Header: "UP", Path: "{.instanceCounts.up}"},
+	{Header: "ZONES", Path: "{.zones}", Wide: true},
+	{Header: "LOAD BALANCERS", Path: "{.loadBalancers}", Wide: true},
+	{Header: "CREATED", Path: "{.createdTime}", Timestamp: true},
+}
+
+func NewGetCmd(serverGroupOptions *serverGroupOptions) *cobra.Command {
+	options := &getOptions{
+		serverGroupOptions: serverGroupOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "get",
+		Short:   getServerGroupShort,
+		Long:    getServerGroupLong,
+		Example: getServerGroupExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return getServerGroup(cmd, options, args)
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.application, "application", "a", "", "Spinnaker application the server group belongs to")
+	cmd.PersistentFlags().StringVar(&options.account, "account", "", "account the server group is deployed to")
+	cmd.PersistentFlags().StringVar(&options.region, "region", "", "region, or namespace for Kubernetes, of the server group")
+
+	return cmd
+}
+
+func getServerGroup(cmd *cobra.Command, options *getOptions, args []string) error {
+	name, err := util.ReadArgsOrStdin(args)
+	if err != nil {
+		return err
+	}
+	if options.application == "" {
+		options.application = options.GateClient.DefaultApplication()
+	}
+	if options.application == "" || options.account == "" || options.region == "" {
+		return errors.New("one of required parameters 'application', 'account' or 'region' not set")
+	}
+
+	serverGroup, resp, err := options.GateClient.ServerGroupControllerApi.GetServerGroupDetailsUsingGET(options.GateClient.Context,
+		options.account,
+		options.application,
+		options.region,
+		name,
+		map[string]interface{}{})
+	if resp != nil {
+		if resp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("Server group '%s' not found in %s/%s\n", name, options.account, options.region)
+		} else if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("Encountered an error getting server group %s, %v\n", name, gateclient.ResponseError(resp, err))
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	options.Ui.TableOutput(serverGroup, serverGroupDetailColumns)
+	return nil
+}
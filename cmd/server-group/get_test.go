@@ -0,0 +1,125 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package server_group
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spinnaker/spin/cmd"
+	"github.com/spinnaker/spin/util"
+)
+
+func TestServerGroupGet_json(t *testing.T) {
+	ts := testGateServerGroupGetSuccess()
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, options := cmd.NewCmdRoot(buffer, ioutil.Discard)
+	rootCmd.AddCommand(NewServerGroupCmd(options))
+
+	args := []string{"server-group", "get", "-a", "app", "--account", "prod", "--region", "us-east-1", "app-main-v002", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	if !strings.Contains(buffer.String(), `"launchConfigName": "app-main-v002-20200602"`) {
+		t.Fatalf("Expected the server group details in output:\n%s", buffer.String())
+	}
+}
+
+func TestServerGroupGet_table(t *testing.T) {
+	ts := testGateServerGroupGetSuccess()
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, options := cmd.NewCmdRoot(buffer, buffer)
+	rootCmd.AddCommand(NewServerGroupCmd(options))
+
+	args := []string{"server-group", "get", "-a", "app", "--account", "prod", "--region", "us-east-1", "app-main-v002", "-o", "wide", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	expected := `NAME            REGION      DISABLED   INSTANCES   UP   ZONES                   LOAD BALANCERS      CREATED
+app-main-v002   us-east-1   false      3           2    us-east-1a,us-east-1b   app-main-frontend   2020-06-02T12:00:00Z`
+	recieved := strings.TrimSpace(buffer.String())
+	if expected != recieved {
+		t.Fatalf("Unexpected table output:\n%s", recieved)
+	}
+}
+
+func TestServerGroupGet_flags(t *testing.T) {
+	ts := testGateServerGroupGetSuccess()
+	defer ts.Close()
+
+	rootCmd, options := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewServerGroupCmd(options))
+
+	args := []string{"server-group", "get", "-a", "app", "--account", "prod", "app-main-v002", "--gate-endpoint=" + ts.URL} // Missing region.
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Expected failure but command succeeded")
+	}
+}
+
+func TestServerGroupGet_notfound(t *testing.T) {
+	ts := testGateServerGroupGetSuccess()
+	defer ts.Close()
+
+	rootCmd, options := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewServerGroupCmd(options))
+
+	args := []string{"server-group", "get", "-a", "app", "--account", "prod", "--region", "us-east-1", "app-main-v009", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("Expected not found error, got: %v", err)
+	}
+}
+
+// testGateServerGroupGetSuccess spins up a local http server that we will configure the GateClient
+// to direct requests to. Responds with the server group app-main-v002 and a 404 for any other.
+func testGateServerGroupGetSuccess() *httptest.Server {
+	mux := util.TestGateMuxWithVersionHandler()
+	mux.Handle("/applications/app/serverGroups/prod/us-east-1/app-main-v002", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, strings.TrimSpace(serverGroupJson))
+	}))
+	mux.Handle("/applications/app/serverGroups/prod/us-east-1/", http.NotFoundHandler())
+	return httptest.NewServer(mux)
+}
+
+const serverGroupJson = `
+{
+  "name": "app-main-v002",
+  "region": "us-east-1",
+  "disabled": false,
+  "zones": ["us-east-1a", "us-east-1b"],
+  "loadBalancers": ["app-main-frontend"],
+  "instanceCounts": {"total": 3, "up": 2, "down": 1},
+  "launchConfig": {"launchConfigName": "app-main-v002-20200602"},
+  "createdTime": 1591099200000
+}
+`
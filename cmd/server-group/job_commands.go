@@ -0,0 +1,85 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package server_group
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// jobCommand is a command submitting a single Orca job that takes no
+// parameters besides the server group.
+type jobCommand struct {
+	use         string
+	short       string
+	long        string
+	jobType     string
+	description string
+}
+
+// jobCommands are the commands built by NewJobCmd.
+var jobCommands = []jobCommand{
+	{
+		use:         "enable",
+		short:       "Enable the specified server group",
+		long:        "Enable the specified server group, adding its instances to load balancers and discovery so that they receive traffic",
+		jobType:     "enableServerGroup",
+		description: "Enable Server Group",
+	},
+	{
+		use:         "disable",
+		short:       "Disable the specified server group",
+		long:        "Disable the specified server group, removing its instances from load balancers and discovery so that they no longer receive traffic",
+		jobType:     "disableServerGroup",
+		description: "Disable Server Group",
+	},
+	{
+		use:         "destroy",
+		short:       "Destroy the specified server group",
+		long:        "Destroy the specified server group and terminate its instances",
+		jobType:     "destroyServerGroup",
+		description: "Destroy Server Group",
+	},
+}
+
+func NewJobCmd(serverGroupOptions *serverGroupOptions, job jobCommand) *cobra.Command {
+	options := &operationOptions{
+		serverGroupOptions: serverGroupOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     job.use,
+		Short:   job.short,
+		Long:    job.long,
+		Example: fmt.Sprintf("usage: spin server-group %s [options] server-group-name", job.use),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runJobCommand(cmd, options, job, args)
+		},
+	}
+
+	addOperationFlags(cmd, options)
+
+	return cmd
+}
+
+func runJobCommand(cmd *cobra.Command, options *operationOptions, job jobCommand, args []string) error {
+	name, err := options.serverGroupName(args)
+	if err != nil {
+		return err
+	}
+
+	return runOperation(options, fmt.Sprintf("%s: %s", job.description, name), options.job(job.jobType, name))
+}
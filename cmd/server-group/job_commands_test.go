@@ -0,0 +1,60 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package server_group
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/spinnaker/spin/cmd"
+)
+
+func TestJobCommands_basic(t *testing.T) {
+	tests := []struct {
+		command     string
+		jobType     string
+		description string
+	}{
+		{command: "enable", jobType: "enableServerGroup", description: "Enable Server Group: app-main-v001"},
+		{command: "disable", jobType: "disableServerGroup", description: "Disable Server Group: app-main-v001"},
+		{command: "destroy", jobType: "destroyServerGroup", description: "Destroy Server Group: app-main-v001"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			var submitted []map[string]interface{}
+			ts := testGateTaskSuccess(&submitted)
+			defer ts.Close()
+
+			rootCmd, options := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+			rootCmd.AddCommand(NewServerGroupCmd(options))
+
+			args := []string{"server-group", tt.command, "-a", "app", "--account", "prod", "--region", "us-east-1", "app-main-v001", "--yes", "--gate-endpoint=" + ts.URL}
+			rootCmd.SetArgs(args)
+			err := rootCmd.Execute()
+			if err != nil {
+				t.Fatalf("Command failed with: %s", err)
+			}
+
+			if description := submitted[0]["description"]; description != tt.description {
+				t.Fatalf("Unexpected task description: %v", description)
+			}
+			job := submittedJob(t, submitted)
+			if job["type"] != tt.jobType || job["serverGroupName"] != "app-main-v001" || job["credentials"] != "prod" {
+				t.Fatalf("Unexpected job: %v", job)
+			}
+		})
+	}
+}
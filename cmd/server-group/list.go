@@ -0,0 +1,124 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package server_group
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+	"github.com/spinnaker/spin/cmd/output"
+)
+
+type listOptions struct {
+	*serverGroupOptions
+	application string
+	account     string
+	region      string
+	cluster     string
+	provider    string
+}
+
+var (
+	listServerGroupShort   = "List the server groups of an application"
+	listServerGroupLong    = "List the server groups of an application, optionally filtered by account, region, cluster and cloud provider"
+	listServerGroupExample = "usage: spin server-group list [options]"
+)
+
+// serverGroupColumns are the columns of table output.
+var serverGroupColumns = []output.Column{
+	{Header: "NAME", Path: "{.name}"},
+	{Header: "ACCOUNT", Path: "{.account}"},
+	{Header: "REGION", Path: "{.region}"},
+	{Header: "DISABLED", Path: "{.isDisabled}"},
+	{Header: "INSTANCES", Path: "{.instanceCounts.total}"},
+	{Header: "UP", Path: "{.instanceCounts.up}", Wide: true},
+	{Header: "CLUSTER", Path: "{.cluster}", Wide: true},
+	{Header: "PROVIDER", Path: "{.cloudProvider}", Wide: true},
+	{Header: "CREATED", Path: "{.createdTime}", Timestamp: true},
+}
+
+func NewListCmd(serverGroupOptions *serverGroupOptions) *cobra.Command {
+	options := &listOptions{
+		serverGroupOptions: serverGroupOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   listServerGroupShort,
+		Long:    listServerGroupLong,
+		Example: listServerGroupExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listServerGroups(cmd, options)
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.application, "application", "a", "", "Spinnaker application to list the server groups of")
+	cmd.PersistentFlags().StringVar(&options.account, "account", "", "only list the server groups in this account")
+	cmd.PersistentFlags().StringVar(&options.region, "region", "", "only list the server groups in this region")
+	cmd.PersistentFlags().StringVar(&options.cluster, "cluster", "", "only list the server groups of this cluster")
+	cmd.PersistentFlags().StringVar(&options.provider, "provider", "", "only list the server groups of this cloud provider")
+
+	return cmd
+}
+
+func listServerGroups(cmd *cobra.Command, options *listOptions) error {
+	if options.application == "" {
+		options.application = options.GateClient.DefaultApplication()
+	}
+	if options.application == "" {
+		return errors.New("required parameter 'application' not set")
+	}
+
+	query := map[string]interface{}{}
+	if options.cluster != "" {
+		query["clusters"] = options.cluster
+	}
+	if options.provider != "" {
+		query["cloudProvider"] = options.provider
+	}
+
+	serverGroups, resp, err := options.GateClient.ServerGroupControllerApi.GetServerGroupsForApplicationUsingGET(options.GateClient.Context, options.application, query)
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Encountered an error listing server groups for application %s, %v\n",
+			options.application,
+			gateclient.ResponseError(resp, err))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	filtered := []interface{}{}
+	for _, sg := range serverGroups {
+		serverGroup, ok := sg.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if options.account != "" && serverGroup["account"] != options.account {
+			continue
+		}
+		if options.region != "" && serverGroup["region"] != options.region {
+			continue
+		}
+		filtered = append(filtered, serverGroup)
+	}
+
+	options.Ui.TableOutput(filtered, serverGroupColumns)
+	return nil
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package server_group
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spinnaker/spin/cmd"
+	"github.com/spinnaker/spin/util"
+)
+
+func TestServerGroupList_table(t *testing.T) {
+	ts := testGateServerGroupListSuccess()
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, options := cmd.NewCmdRoot(buffer, buffer)
+	rootCmd.AddCommand(NewServerGroupCmd(options))
+
+	args := []string{"server-group", "list", "-a", "app", "-o", "table", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	expected := `NAME            ACCOUNT   REGION      DISABLED   INSTANCES   CREATED
+app-main-v001   prod      us-east-1   true       0           2020-06-01T12:00:00Z
+app-main-v002   prod      us-east-1   false      3           2020-06-02T12:00:00Z
+app-main-v007   test      us-west-2   false      1           2020-06-03T12:00:00Z`
+	recieved := strings.TrimSpace(buffer.String())
+	if expected != recieved {
+		t.Fatalf("Unexpected table output:\n%s", recieved)
+	}
+}
+
+func TestServerGroupList_filtered(t *testing.T) {
+	ts := testGateServerGroupListSuccess()
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, options := cmd.NewCmdRoot(buffer, buffer)
+	rootCmd.AddCommand(NewServerGroupCmd(options))
+
+	args := []string{"server-group", "list", "-a", "app", "--account", "prod", "--region", "us-east-1", "-o", "table", "--no-headers", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	expected := `app-main-v001   prod   us-east-1   true    0   2020-06-01T12:00:00Z
+app-main-v002   prod   us-east-1   false   3   2020-06-02T12:00:00Z`
+	recieved := strings.TrimSpace(buffer.String())
+	if expected != recieved {
+		t.Fatalf("Unexpected table output:\n%s", recieved)
+	}
+}
+
+func TestServerGroupList_fail(t *testing.T) {
+	ts := testGateFail()
+	defer ts.Close()
+
+	rootCmd, options := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewServerGroupCmd(options))
+
+	args := []string{"server-group", "list", "-a", "app", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Expected failure but command succeeded")
+	}
+}
+
+// testGateServerGroupListSuccess spins up a local http server that we will configure the GateClient
+// to direct requests to. Responds with the server groups of the application.
+func testGateServerGroupListSuccess() *httptest.Server {
+	mux := util.TestGateMuxWithVersionHandler()
+	mux.Handle("/applications/app/serverGroups", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, strings.TrimSpace(serverGroupListJson))
+	}))
+	return httptest.NewServer(mux)
+}
+
+// testGateFail spins up a local http server that we will configure the GateClient
+// to direct requests to. Responds with a 500 InternalServerError.
+func testGateFail() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}))
+}
+
+const serverGroupListJson = `
+[
+  {
+    "name": "app-main-v001",
+    "account": "prod",
+    "region": "us-east-1",
+    "cluster": "app-main",
+    "cloudProvider": "aws",
+    "isDisabled": true,
+    "instanceCounts": {"total": 0, "up": 0},
+    "createdTime": 1591012800000
+  },
+  {
+    "name": "app-main-v002",
+    "account": "prod",
+    "region": "us-east-1",
+    "cluster": "app-main",
+    "cloudProvider": "aws",
+    "isDisabled": false,
+    "instanceCounts": {"total": 3, "up": 3},
+    "createdTime": 1591099200000
+  },
+  {
+    "name": "app-main-v007",
+    "account": "test",
+    "region": "us-west-2",
+    "cluster": "app-main",
+    "cloudProvider": "aws",
+    "isDisabled": false,
+    "instanceCounts": {"total": 1, "up": 1},
+    "createdTime": 1591185600000
+  }
+]
+`
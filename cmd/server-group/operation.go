@@ -0,0 +1,127 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package server_group
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+	orca_tasks "github.com/spinnaker/spin/cmd/orca-tasks"
+	"github.com/spinnaker/spin/cmd/output"
+	"github.com/spinnaker/spin/util"
+)
+
+// operationTaskAttempts is the number of polls of an operation's task, which
+// waits about six minutes in total.
+const operationTaskAttempts = 10
+
+// operationOptions are the options of the commands operating on a server
+// group through an Orca task.
+type operationOptions struct {
+	*serverGroupOptions
+	application string
+	account     string
+	region      string
+	provider    string
+	yes         bool
+	dryRun      bool
+}
+
+func addOperationFlags(cmd *cobra.Command, options *operationOptions) {
+	cmd.PersistentFlags().StringVarP(&options.application, "application", "a", "", "Spinnaker application the server group belongs to")
+	cmd.PersistentFlags().StringVar(&options.account, "account", "", "account the server group is deployed to")
+	cmd.PersistentFlags().StringVar(&options.region, "region", "", "region, or namespace for Kubernetes, of the server group")
+	cmd.PersistentFlags().StringVar(&options.provider, "provider", "aws", "cloud provider of the server group")
+	cmd.PersistentFlags().BoolVarP(&options.yes, "yes", "y", false, "do not ask for confirmation")
+	cmd.PersistentFlags().BoolVar(&options.dryRun, "dry-run", false, "print the task that would be submitted instead of submitting it")
+}
+
+// serverGroupName returns the server group given as an argument, after
+// checking that the required flags are set.
+func (o *operationOptions) serverGroupName(args []string) (string, error) {
+	name, err := util.ReadArgsOrStdin(args)
+	if err != nil {
+		return "", err
+	}
+	if o.application == "" {
+		o.application = o.GateClient.DefaultApplication()
+	}
+	if o.application == "" || o.account == "" || o.region == "" {
+		return "", errors.New("one of required parameters 'application', 'account' or 'region' not set")
+	}
+	return name, nil
+}
+
+// job returns an Orca job of the type operating on the server group.
+func (o *operationOptions) job(jobType, name string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":            jobType,
+		"serverGroupName": name,
+		"asgName":         name,
+		"credentials":     o.account,
+		"cloudProvider":   o.provider,
+		"region":          o.region,
+		"regions":         []interface{}{o.region},
+	}
+}
+
+// runOperation submits a task running the job and waits for it to succeed.
+// The user is asked to confirm the description of the task first, unless
+// --yes is set. With --dry-run the task is printed instead.
+func runOperation(options *operationOptions, description string, job map[string]interface{}) error {
+	task := map[string]interface{}{
+		"application": options.application,
+		"description": description,
+		"job":         []interface{}{job},
+	}
+	if options.dryRun {
+		options.Ui.JsonOutput(task)
+		return nil
+	}
+
+	if !options.yes {
+		confirmed, err := confirm(options, description)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return errors.New("Aborted")
+		}
+	}
+
+	taskRef, resp, err := options.GateClient.TaskControllerApi.TaskUsingPOST1(options.GateClient.Context, task)
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Encountered an error submitting task %q, %v\n", description, gateclient.ResponseError(resp, err))
+	}
+	if err != nil {
+		return err
+	}
+
+	options.Ui.Info(fmt.Sprintf("Submitted task %v, waiting for completion...", taskRef["ref"]))
+	if err := orca_tasks.WaitForSuccessfulTask(options.GateClient, taskRef, operationTaskAttempts); err != nil {
+		return err
+	}
+
+	options.Ui.Success(fmt.Sprintf("%s succeeded", description))
+	return nil
+}
+
+// confirm asks the user whether to run the task.
+func confirm(options *operationOptions, description string) (bool, error) {
+	return output.Confirm(options.Ui, fmt.Sprintf("%s in %s/%s? [y/N]", description, options.account, options.region))
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package server_group
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spinnaker/spin/cmd"
+	"github.com/spinnaker/spin/util"
+)
+
+func TestOperation_confirmed(t *testing.T) {
+	var submitted []map[string]interface{}
+	ts := testGateTaskSuccess(&submitted)
+	defer ts.Close()
+
+	rootCmd, options := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewServerGroupCmd(options))
+	rootCmd.SetIn(strings.NewReader("y\n"))
+
+	args := []string{"server-group", "disable", "-a", "app", "--account", "prod", "--region", "us-east-1", "app-main-v001", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+	if len(submitted) != 1 {
+		t.Fatalf("Expected a task to be submitted, got %d", len(submitted))
+	}
+}
+
+func TestOperation_declined(t *testing.T) {
+	var submitted []map[string]interface{}
+	ts := testGateTaskSuccess(&submitted)
+	defer ts.Close()
+
+	for _, input := range []string{"n\n", "\n", ""} {
+		rootCmd, options := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+		rootCmd.AddCommand(NewServerGroupCmd(options))
+		rootCmd.SetIn(strings.NewReader(input))
+
+		args := []string{"server-group", "disable", "-a", "app", "--account", "prod", "--region", "us-east-1", "app-main-v001", "--gate-endpoint=" + ts.URL}
+		rootCmd.SetArgs(args)
+		err := rootCmd.Execute()
+		if err == nil {
+			t.Fatalf("Expected failure for input %q but command succeeded", input)
+		}
+	}
+	if len(submitted) != 0 {
+		t.Fatalf("Expected no task to be submitted, got %d", len(submitted))
+	}
+}
+
+func TestOperation_taskFailed(t *testing.T) {
+	ts := testGateTaskTerminal()
+	defer ts.Close()
+
+	rootCmd, options := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewServerGroupCmd(options))
+
+	args := []string{"server-group", "enable", "-a", "app", "--account", "prod", "--region", "us-east-1", "app-main-v001", "--yes", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Expected failure but command succeeded")
+	}
+}
+
+func TestOperation_flags(t *testing.T) {
+	var submitted []map[string]interface{}
+	ts := testGateTaskSuccess(&submitted)
+	defer ts.Close()
+
+	rootCmd, options := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewServerGroupCmd(options))
+
+	args := []string{"server-group", "destroy", "-a", "app", "--region", "us-east-1", "app-main-v001", "--yes", "--gate-endpoint=" + ts.URL} // Missing account.
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Expected failure but command succeeded")
+	}
+	if len(submitted) != 0 {
+		t.Fatalf("Expected no task to be submitted, got %d", len(submitted))
+	}
+}
+
+// submittedJob returns the single job of the single submitted task.
+func submittedJob(t *testing.T, submitted []map[string]interface{}) map[string]interface{} {
+	if len(submitted) != 1 {
+		t.Fatalf("Expected a task to be submitted, got %d", len(submitted))
+	}
+	jobs, _ := submitted[0]["job"].([]interface{})
+	if len(jobs) != 1 {
+		t.Fatalf("Expected a single job, got: %v", submitted[0])
+	}
+	return jobs[0].(map[string]interface{})
+}
+
+// testGateTaskSuccess spins up a local http server that we will configure the GateClient
+// to direct requests to. Records submitted tasks and responds that they succeeded.
+func testGateTaskSuccess(submitted *[]map[string]interface{}) *httptest.Server {
+	return testGateTask(submitted, "SUCCEEDED")
+}
+
+// testGateTaskTerminal responds that submitted tasks failed.
+func testGateTaskTerminal() *httptest.Server {
+	return testGateTask(&[]map[string]interface{}{}, "TERMINAL")
+}
+
+func testGateTask(submitted *[]map[string]interface{}, status string) *httptest.Server {
+	mux := util.TestGateMuxWithVersionHandler()
+	mux.Handle("/tasks", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var task map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&task); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		*submitted = append(*submitted, task)
+		fmt.Fprintln(w, `{"ref": "/tasks/id"}`)
+	}))
+	mux.Handle("/tasks/id", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"id": "id", "status": %q}`, status)
+	}))
+	mux.Handle("/applications/app/clusters/prod/app-main/aws/us-east-1/serverGroups/target/ancestor_asg_dynamic", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"name": "app-main-v001", "region": "us-east-1"}`)
+	}))
+	return httptest.NewServer(mux)
+}
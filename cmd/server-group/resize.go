@@ -0,0 +1,87 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package server_group
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+type resizeOptions struct {
+	*operationOptions
+	min     int
+	max     int
+	desired int
+}
+
+var (
+	resizeServerGroupShort   = "Resize the specified server group"
+	resizeServerGroupLong    = "Resize the specified server group to the given capacity. --min and --max default to --desired."
+	resizeServerGroupExample = "usage: spin server-group resize [options] server-group-name"
+)
+
+func NewResizeCmd(serverGroupOptions *serverGroupOptions) *cobra.Command {
+	options := &resizeOptions{
+		operationOptions: &operationOptions{serverGroupOptions: serverGroupOptions},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "resize",
+		Short:   resizeServerGroupShort,
+		Long:    resizeServerGroupLong,
+		Example: resizeServerGroupExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return resizeServerGroup(cmd, options, args)
+		},
+	}
+
+	addOperationFlags(cmd, options.operationOptions)
+	cmd.PersistentFlags().IntVar(&options.min, "min", 0, "minimum number of instances")
+	cmd.PersistentFlags().IntVar(&options.max, "max", 0, "maximum number of instances")
+	cmd.PersistentFlags().IntVar(&options.desired, "desired", 0, "desired number of instances")
+
+	return cmd
+}
+
+func resizeServerGroup(cmd *cobra.Command, options *resizeOptions, args []string) error {
+	name, err := options.serverGroupName(args)
+	if err != nil {
+		return err
+	}
+	if !cmd.Flags().Changed("desired") {
+		return errors.New("required parameter 'desired' not set")
+	}
+	if !cmd.Flags().Changed("min") {
+		options.min = options.desired
+	}
+	if !cmd.Flags().Changed("max") {
+		options.max = options.desired
+	}
+	if options.min < 0 || options.min > options.desired || options.desired > options.max {
+		return fmt.Errorf("Invalid capacity, expected 0 <= min (%d) <= desired (%d) <= max (%d)\n",
+			options.min, options.desired, options.max)
+	}
+
+	job := options.job("resizeServerGroup", name)
+	job["capacity"] = map[string]interface{}{
+		"min":     options.min,
+		"max":     options.max,
+		"desired": options.desired,
+	}
+	description := fmt.Sprintf("Resize Server Group: %s to %d/%d/%d", name, options.min, options.desired, options.max)
+	return runOperation(options.operationOptions, description, job)
+}
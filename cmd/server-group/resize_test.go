@@ -0,0 +1,109 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package server_group
+
+import (
+	"bytes"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spinnaker/spin/cmd"
+)
+
+func TestServerGroupResize_basic(t *testing.T) {
+	var submitted []map[string]interface{}
+	ts := testGateTaskSuccess(&submitted)
+	defer ts.Close()
+
+	rootCmd, options := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewServerGroupCmd(options))
+
+	args := []string{"server-group", "resize", "-a", "app", "--account", "prod", "--region", "us-east-1", "app-main-v002", "--desired", "3", "--max", "5", "--yes", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	if description := submitted[0]["description"]; description != "Resize Server Group: app-main-v002 to 3/3/5" {
+		t.Fatalf("Unexpected task description: %v", description)
+	}
+	job := submittedJob(t, submitted)
+	expected := map[string]interface{}{
+		"type":            "resizeServerGroup",
+		"serverGroupName": "app-main-v002",
+		"asgName":         "app-main-v002",
+		"credentials":     "prod",
+		"cloudProvider":   "aws",
+		"region":          "us-east-1",
+		"regions":         []interface{}{"us-east-1"},
+		"capacity":        map[string]interface{}{"min": 3.0, "max": 5.0, "desired": 3.0},
+	}
+	if !reflect.DeepEqual(job, expected) {
+		t.Fatalf("Unexpected job: %v", job)
+	}
+}
+
+func TestServerGroupResize_dryRun(t *testing.T) {
+	var submitted []map[string]interface{}
+	ts := testGateTaskSuccess(&submitted)
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, options := cmd.NewCmdRoot(buffer, ioutil.Discard)
+	rootCmd.AddCommand(NewServerGroupCmd(options))
+
+	args := []string{"server-group", "resize", "-a", "app", "--account", "prod", "--region", "us-east-1", "app-main-v002", "--desired", "0", "--dry-run", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	if len(submitted) != 0 {
+		t.Fatalf("Expected no task to be submitted, got %d", len(submitted))
+	}
+	if !strings.Contains(buffer.String(), `"type": "resizeServerGroup"`) ||
+		!strings.Contains(buffer.String(), `"description": "Resize Server Group: app-main-v002 to 0/0/0"`) {
+		t.Fatalf("Expected the task in output:\n%s", buffer.String())
+	}
+}
+
+func TestServerGroupResize_invalidCapacity(t *testing.T) {
+	var submitted []map[string]interface{}
+	ts := testGateTaskSuccess(&submitted)
+	defer ts.Close()
+
+	for _, capacity := range [][]string{
+		{"--min", "1"},
+		{"--desired", "3", "--max", "2"},
+		{"--desired", "1", "--min", "2", "--max", "4"},
+	} {
+		rootCmd, options := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+		rootCmd.AddCommand(NewServerGroupCmd(options))
+
+		args := []string{"server-group", "resize", "-a", "app", "--account", "prod", "--region", "us-east-1", "app-main-v002", "--yes", "--gate-endpoint=" + ts.URL}
+		rootCmd.SetArgs(append(args, capacity...))
+		err := rootCmd.Execute()
+		if err == nil {
+			t.Fatalf("Expected failure for %v but command succeeded", capacity)
+		}
+	}
+	if len(submitted) != 0 {
+		t.Fatalf("Expected no task to be submitted, got %d", len(submitted))
+	}
+}
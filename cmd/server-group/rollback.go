@@ -0,0 +1,115 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package server_group
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+)
+
+type rollbackOptions struct {
+	*operationOptions
+	restore           string
+	healthyPercentage int
+}
+
+var (
+	rollbackServerGroupShort   = "Roll back the specified server group"
+	rollbackServerGroupLong    = "Roll back the specified server group to another server group of its cluster, by default the one deployed before the current server group. The restored server group is enabled and resized to the capacity of the rolled back one, which is then disabled."
+	rollbackServerGroupExample = "usage: spin server-group rollback [options] server-group-name"
+)
+
+func NewRollbackCmd(serverGroupOptions *serverGroupOptions) *cobra.Command {
+	options := &rollbackOptions{
+		operationOptions: &operationOptions{serverGroupOptions: serverGroupOptions},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "rollback",
+		Short:   rollbackServerGroupShort,
+		Long:    rollbackServerGroupLong,
+		Example: rollbackServerGroupExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return rollbackServerGroup(cmd, options, args)
+		},
+	}
+
+	addOperationFlags(cmd, options.operationOptions)
+	cmd.PersistentFlags().StringVar(&options.restore, "to", "", "server group to restore (default is the previous server group of the cluster)")
+	cmd.PersistentFlags().IntVar(&options.healthyPercentage, "healthy-percentage", 100, "percentage of instances of the restored server group that must be healthy")
+
+	return cmd
+}
+
+func rollbackServerGroup(cmd *cobra.Command, options *rollbackOptions, args []string) error {
+	name, err := options.serverGroupName(args)
+	if err != nil {
+		return err
+	}
+	if options.restore == "" {
+		options.restore, err = previousServerGroup(options, clusterOf(name))
+		if err != nil {
+			return err
+		}
+	}
+	if options.restore == name {
+		return fmt.Errorf("Cannot roll back server group %s to itself\n", name)
+	}
+
+	job := options.job("rollbackServerGroup", name)
+	job["rollbackType"] = "EXPLICIT"
+	job["rollbackContext"] = map[string]interface{}{
+		"rollbackServerGroupName":         name,
+		"restoreServerGroupName":          options.restore,
+		"targetHealthyRollbackPercentage": options.healthyPercentage,
+	}
+	description := fmt.Sprintf("Rollback Server Group: %s to %s", name, options.restore)
+	return runOperation(options.operationOptions, description, job)
+}
+
+// previousServerGroup returns the name of the server group deployed before
+// the current server group of the cluster.
+func previousServerGroup(options *rollbackOptions, cluster string) (string, error) {
+	serverGroup, resp, err := options.GateClient.ClusterControllerApi.GetTargetServerGroupUsingGET(options.GateClient.Context,
+		options.account,
+		options.application,
+		options.provider,
+		cluster,
+		options.region,
+		"ancestor_asg_dynamic",
+		map[string]interface{}{})
+	if resp != nil {
+		if resp.StatusCode == http.StatusNotFound {
+			return "", fmt.Errorf("No previous server group of cluster '%s' found to roll back to, use --to to select one\n", cluster)
+		} else if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("Encountered an error getting the previous server group of cluster %s, %v\n",
+				cluster,
+				gateclient.ResponseError(resp, err))
+		}
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	name, _ := serverGroup["name"].(string)
+	if name == "" {
+		return "", fmt.Errorf("No previous server group of cluster '%s' found to roll back to, use --to to select one\n", cluster)
+	}
+	return name, nil
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package server_group
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/spinnaker/spin/cmd"
+)
+
+func TestServerGroupRollback_explicit(t *testing.T) {
+	var submitted []map[string]interface{}
+	ts := testGateTaskSuccess(&submitted)
+	defer ts.Close()
+
+	rootCmd, options := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewServerGroupCmd(options))
+
+	args := []string{"server-group", "rollback", "-a", "app", "--account", "prod", "--region", "us-east-1", "app-main-v003", "--to", "app-main-v000", "--healthy-percentage", "90", "--yes", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	job := submittedJob(t, submitted)
+	expected := map[string]interface{}{
+		"rollbackServerGroupName":         "app-main-v003",
+		"restoreServerGroupName":          "app-main-v000",
+		"targetHealthyRollbackPercentage": 90.0,
+	}
+	if job["type"] != "rollbackServerGroup" || job["rollbackType"] != "EXPLICIT" || !reflect.DeepEqual(job["rollbackContext"], expected) {
+		t.Fatalf("Unexpected job: %v", job)
+	}
+}
+
+func TestServerGroupRollback_previous(t *testing.T) {
+	var submitted []map[string]interface{}
+	ts := testGateTaskSuccess(&submitted)
+	defer ts.Close()
+
+	rootCmd, options := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewServerGroupCmd(options))
+
+	args := []string{"server-group", "rollback", "-a", "app", "--account", "prod", "--region", "us-east-1", "app-main-v002", "--yes", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	if description := submitted[0]["description"]; description != "Rollback Server Group: app-main-v002 to app-main-v001" {
+		t.Fatalf("Unexpected task description: %v", description)
+	}
+}
+
+func TestServerGroupRollback_self(t *testing.T) {
+	var submitted []map[string]interface{}
+	ts := testGateTaskSuccess(&submitted)
+	defer ts.Close()
+
+	rootCmd, options := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewServerGroupCmd(options))
+
+	// The previous server group of the cluster is the one rolled back.
+	args := []string{"server-group", "rollback", "-a", "app", "--account", "prod", "--region", "us-east-1", "app-main-v001", "--yes", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Expected failure but command succeeded")
+	}
+	if len(submitted) != 0 {
+		t.Fatalf("Expected no task to be submitted, got %d", len(submitted))
+	}
+}
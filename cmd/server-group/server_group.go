@@ -0,0 +1,54 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package server_group
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd"
+)
+
+type serverGroupOptions struct {
+	*cmd.RootOptions
+}
+
+var (
+	serverGroupShort   = ""
+	serverGroupLong    = ""
+	serverGroupExample = ""
+)
+
+func NewServerGroupCmd(rootOptions *cmd.RootOptions) *cobra.Command {
+	options := &serverGroupOptions{
+		RootOptions: rootOptions,
+	}
+	cmd := &cobra.Command{
+		Use:     "server-group",
+		Aliases: []string{"server-groups", "sg"},
+		Short:   serverGroupShort,
+		Long:    serverGroupLong,
+		Example: serverGroupExample,
+	}
+
+	// create subcommands
+	cmd.AddCommand(NewActivitiesCmd(options))
+	cmd.AddCommand(NewGetCmd(options))
+	cmd.AddCommand(NewListCmd(options))
+	cmd.AddCommand(NewResizeCmd(options))
+	for _, job := range jobCommands {
+		cmd.AddCommand(NewJobCmd(options, job))
+	}
+	cmd.AddCommand(NewRollbackCmd(options))
+	return cmd
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package subnet
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd/gateclient"
+	"github.com/spinnaker/spin/cmd/output"
+)
+
+type listOptions struct {
+	*subnetOptions
+	provider string
+	account  string
+	region   string
+}
+
+var (
+	listSubnetShort   = "List the subnets of a cloud provider"
+	listSubnetLong    = "List the subnets of a cloud provider, optionally only those in an account and region"
+	listSubnetExample = "usage: spin subnet list [options]"
+)
+
+// subnetColumns are the columns of table output.
+var subnetColumns = []output.Column{
+	{Header: "ID", Path: "{.id}"},
+	{Header: "ACCOUNT", Path: "{.account}"},
+	{Header: "REGION", Path: "{.region}"},
+	{Header: "ZONE", Path: "{.availabilityZone}"},
+	{Header: "VPC", Path: "{.vpcId}"},
+	{Header: "PURPOSE", Path: "{.purpose}"},
+	{Header: "CIDR", Path: "{.cidrBlock}", Wide: true},
+	{Header: "TARGET", Path: "{.target}", Wide: true},
+}
+
+func NewListCmd(subnetOptions *subnetOptions) *cobra.Command {
+	options := &listOptions{
+		subnetOptions: subnetOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   listSubnetShort,
+		Long:    listSubnetLong,
+		Example: listSubnetExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listSubnets(cmd, options)
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&options.provider, "provider", "aws", "cloud provider to list the subnets of")
+	cmd.PersistentFlags().StringVar(&options.account, "account", "", "only list the subnets in this account")
+	cmd.PersistentFlags().StringVar(&options.region, "region", "", "only list the subnets in this region")
+
+	return cmd
+}
+
+func listSubnets(cmd *cobra.Command, options *listOptions) error {
+	subnets, resp, err := options.GateClient.SubnetControllerApi.AllByCloudProviderUsingGET1(options.GateClient.Context, options.provider, map[string]interface{}{})
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Encountered an error listing subnets, %v\n", gateclient.ResponseError(resp, err))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	filtered := []map[string]interface{}{}
+	for _, s := range subnets {
+		subnet, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if options.account != "" && subnet["account"] != options.account {
+			continue
+		}
+		if options.region != "" && subnet["region"] != options.region {
+			continue
+		}
+		filtered = append(filtered, subnet)
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return sortKey(filtered[i]) < sortKey(filtered[j])
+	})
+
+	options.Ui.TableOutput(filtered, subnetColumns)
+	return nil
+}
+
+// sortKey orders subnets by account, region, availability zone and id.
+func sortKey(subnet map[string]interface{}) string {
+	return fmt.Sprintf("%v\x00%v\x00%v\x00%v", subnet["account"], subnet["region"], subnet["availabilityZone"], subnet["id"])
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package subnet
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spinnaker/spin/cmd"
+	"github.com/spinnaker/spin/util"
+)
+
+func TestSubnetList_basic(t *testing.T) {
+	ts := testGateSubnetListSuccess()
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, options := cmd.NewCmdRoot(buffer, buffer)
+	rootCmd.AddCommand(NewSubnetCmd(options))
+
+	args := []string{"subnet", "list", "-o", "wide", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	expected := `ID            ACCOUNT   REGION      ZONE         VPC        PURPOSE           CIDR          TARGET
+subnet-0001   prod      us-east-1   us-east-1a   vpc-0001   internal (vpc0)   10.0.0.0/24   ec2
+subnet-0002   prod      us-east-1   us-east-1b   vpc-0001   external (vpc0)   10.0.1.0/24   elb
+subnet-0003   prod      us-west-2   us-west-2a   vpc-0002   internal (vpc0)   10.1.0.0/24   ec2
+subnet-0004   test      us-east-1   us-east-1a   vpc-0003                     10.2.0.0/24`
+	recieved := strings.TrimSpace(buffer.String())
+	if expected != recieved {
+		t.Fatalf("Unexpected table output:\n%s", recieved)
+	}
+}
+
+func TestSubnetList_filtered(t *testing.T) {
+	ts := testGateSubnetListSuccess()
+	defer ts.Close()
+
+	buffer := new(bytes.Buffer)
+	rootCmd, options := cmd.NewCmdRoot(buffer, buffer)
+	rootCmd.AddCommand(NewSubnetCmd(options))
+
+	args := []string{"subnet", "list", "--provider", "aws", "--account", "prod", "--region", "us-east-1", "-o", "table", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("Command failed with: %s", err)
+	}
+
+	expected := `ID            ACCOUNT   REGION      ZONE         VPC        PURPOSE
+subnet-0001   prod      us-east-1   us-east-1a   vpc-0001   internal (vpc0)
+subnet-0002   prod      us-east-1   us-east-1b   vpc-0001   external (vpc0)`
+	recieved := strings.TrimSpace(buffer.String())
+	if expected != recieved {
+		t.Fatalf("Unexpected table output:\n%s", recieved)
+	}
+}
+
+func TestSubnetList_fail(t *testing.T) {
+	ts := testGateFail()
+	defer ts.Close()
+
+	rootCmd, options := cmd.NewCmdRoot(ioutil.Discard, ioutil.Discard)
+	rootCmd.AddCommand(NewSubnetCmd(options))
+
+	args := []string{"subnet", "list", "--provider", "gce", "--gate-endpoint=" + ts.URL}
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatalf("Expected failure but command succeeded")
+	}
+}
+
+// testGateSubnetListSuccess spins up a local http server that we will configure the GateClient
+// to direct requests to. Responds with the subnets of aws.
+func testGateSubnetListSuccess() *httptest.Server {
+	mux := util.TestGateMuxWithVersionHandler()
+	mux.Handle("/subnets/aws", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, strings.TrimSpace(subnetsJson))
+	}))
+	return httptest.NewServer(mux)
+}
+
+// testGateFail spins up a local http server that we will configure the GateClient
+// to direct requests to. Responds with a 500 InternalServerError.
+func testGateFail() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}))
+}
+
+const subnetsJson = `
+[
+  {"id": "subnet-0004", "account": "test", "region": "us-east-1", "availabilityZone": "us-east-1a", "vpcId": "vpc-0003", "cidrBlock": "10.2.0.0/24", "type": "aws"},
+  {"id": "subnet-0003", "account": "prod", "region": "us-west-2", "availabilityZone": "us-west-2a", "vpcId": "vpc-0002", "purpose": "internal (vpc0)", "target": "ec2", "cidrBlock": "10.1.0.0/24", "type": "aws"},
+  {"id": "subnet-0002", "account": "prod", "region": "us-east-1", "availabilityZone": "us-east-1b", "vpcId": "vpc-0001", "purpose": "external (vpc0)", "target": "elb", "cidrBlock": "10.0.1.0/24", "type": "aws"},
+  {"id": "subnet-0001", "account": "prod", "region": "us-east-1", "availabilityZone": "us-east-1a", "vpcId": "vpc-0001", "purpose": "internal (vpc0)", "target": "ec2", "cidrBlock": "10.0.0.0/24", "type": "aws"}
+]
+`
@@ -0,0 +1,47 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package subnet
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spinnaker/spin/cmd"
+)
+
+type subnetOptions struct {
+	*cmd.RootOptions
+}
+
+var (
+	subnetShort   = ""
+	subnetLong    = ""
+	subnetExample = ""
+)
+
+func NewSubnetCmd(rootOptions *cmd.RootOptions) *cobra.Command {
+	options := &subnetOptions{
+		RootOptions: rootOptions,
+	}
+	cmd := &cobra.Command{
+		Use:     "subnet",
+		Aliases: []string{"subnets"},
+		Short:   subnetShort,
+		Long:    subnetLong,
+		Example: subnetExample,
+	}
+
+	// create subcommands
+	cmd.AddCommand(NewListCmd(options))
+	return cmd
+}
@@ -16,6 +16,8 @@ package auth
 
 import (
 	"github.com/spinnaker/spin/config/auth/basic"
+	"github.com/spinnaker/spin/config/auth/bearer"
+	"github.com/spinnaker/spin/config/auth/exec"
 	gsa "github.com/spinnaker/spin/config/auth/googleserviceaccount"
 	config "github.com/spinnaker/spin/config/auth/iap"
 	"github.com/spinnaker/spin/config/auth/ldap"
@@ -31,6 +33,64 @@ type Config struct {
 	Basic   *basic.Config  `yaml:"basic,omitempty"`
 	Iap     *config.Config `yaml:"iap,omitempty"`
 	Ldap    *ldap.Config   `yaml:"ldap,omitempty"`
+	Exec    *exec.Config   `yaml:"exec,omitempty"`
+	Bearer  *bearer.Config `yaml:"bearer,omitempty"`
 
 	GoogleServiceAccount *gsa.Config `yaml:"google_service_account,omitempty"`
 }
+
+// Methods returns the names of the configured authentication methods, using
+// their config keys.
+func (a *Config) Methods() []string {
+	if a == nil || !a.Enabled {
+		return nil
+	}
+	var methods []string
+	if a.X509 != nil {
+		methods = append(methods, "x509")
+	}
+	if a.OAuth2 != nil {
+		methods = append(methods, "oauth2")
+	}
+	if a.Basic != nil {
+		methods = append(methods, "basic")
+	}
+	if a.Iap != nil {
+		methods = append(methods, "iap")
+	}
+	if a.Ldap != nil {
+		methods = append(methods, "ldap")
+	}
+	if a.Exec != nil {
+		methods = append(methods, "exec")
+	}
+	if a.Bearer != nil {
+		methods = append(methods, "bearer")
+	}
+	if a.GoogleServiceAccount != nil {
+		methods = append(methods, "google_service_account")
+	}
+	return methods
+}
+
+// ClearCachedTokens removes any cached OAuth2, Google service account and exec
+// tokens, reporting whether there were any.
+func (a *Config) ClearCachedTokens() bool {
+	if a == nil {
+		return false
+	}
+	cleared := false
+	if a.OAuth2 != nil && a.OAuth2.CachedToken != nil {
+		a.OAuth2.CachedToken = nil
+		cleared = true
+	}
+	if a.GoogleServiceAccount != nil && a.GoogleServiceAccount.CachedToken != nil {
+		a.GoogleServiceAccount.CachedToken = nil
+		cleared = true
+	}
+	if a.Exec != nil && a.Exec.CachedToken != nil {
+		a.Exec.CachedToken = nil
+		cleared = true
+	}
+	return cleared
+}
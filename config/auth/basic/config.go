@@ -17,6 +17,9 @@ package basic
 type Config struct {
 	Username string `yaml:"username"`
 	Password string `yaml:"password"`
+
+	// PasswordRef references the password in the credential store.
+	PasswordRef string `yaml:"passwordRef,omitempty"`
 }
 
 func (b *Config) IsValid() bool {
@@ -0,0 +1,66 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+// Package bearer contains a spin CLI config structure to authenticate with a
+// pre-issued access token.
+package bearer
+
+import (
+	"errors"
+	"io/ioutil"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+// EnvToken is the environment variable holding a bearer token, which takes
+// precedence over the configured authentication.
+const EnvToken = "SPIN_TOKEN"
+
+// Config is the configuration for authenticating with a static bearer token,
+// given either inline or in a file. The file is read on every invocation so
+// that rotated tokens are picked up.
+type Config struct {
+	Token     string `yaml:"token,omitempty"`
+	TokenFile string `yaml:"tokenFile,omitempty"`
+
+	// TokenRef references the token in the credential store.
+	TokenRef string `yaml:"tokenRef,omitempty"`
+}
+
+func (x *Config) IsValid() bool {
+	return x.Token != "" || x.TokenFile != ""
+}
+
+// AccessToken returns the token, reading it from TokenFile if no Token is
+// set.
+func (x *Config) AccessToken() (string, error) {
+	if x.Token != "" {
+		return x.Token, nil
+	}
+
+	path, err := homedir.Expand(x.TokenFile)
+	if err != nil {
+		return "", err
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	token := strings.TrimSpace(string(b))
+	if token == "" {
+		return "", errors.New("bearer token file " + x.TokenFile + " is empty")
+	}
+	return token, nil
+}
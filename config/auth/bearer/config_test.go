@@ -0,0 +1,60 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package bearer
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestAccessToken(t *testing.T) {
+	cfg := &Config{Token: "secret"}
+	token, err := cfg.AccessToken()
+	if err != nil || token != "secret" {
+		t.Fatalf("Unexpected token %q, error: %v", token, err)
+	}
+}
+
+func TestAccessToken_file(t *testing.T) {
+	tempFile, err := ioutil.TempFile("", "spin-token")
+	if err != nil {
+		t.Fatalf("Could not create temp file: %v", err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	cfg := &Config{TokenFile: tempFile.Name()}
+	if _, err := cfg.AccessToken(); err == nil {
+		t.Fatalf("Expected an error for an empty token file")
+	}
+
+	// The file is read again after the token is rotated.
+	for _, expected := range []string{"first", "second"} {
+		if err := ioutil.WriteFile(tempFile.Name(), []byte(expected+"\n"), 0600); err != nil {
+			t.Fatalf("Could not write token file: %v", err)
+		}
+		token, err := cfg.AccessToken()
+		if err != nil || token != expected {
+			t.Fatalf("Unexpected token %q, error: %v", token, err)
+		}
+	}
+}
+
+func TestAccessToken_missingFile(t *testing.T) {
+	cfg := &Config{TokenFile: "/does/not/exist"}
+	if _, err := cfg.AccessToken(); err == nil {
+		t.Fatalf("Expected an error for a missing token file")
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+// Package exec contains a spin CLI config structure to obtain bearer tokens
+// from an external command, similar to client-go credential plugins.
+package exec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+	"golang.org/x/oauth2"
+)
+
+// Config is the configuration for authenticating with a token printed by a
+// command. The command must write a JSON object with a "token" and an
+// optional RFC 3339 "expiry" to stdout. Tokens with an expiry are cached
+// until they expire, others are requested again on every invocation.
+type Config struct {
+	Command string            `yaml:"command"`
+	Args    []string          `yaml:"args,omitempty"`
+	Env     map[string]string `yaml:"env,omitempty"`
+
+	CachedToken *oauth2.Token `yaml:"cachedToken,omitempty"`
+
+	// CachedTokenRef references the cached token in the credential store.
+	CachedTokenRef string `yaml:"cachedTokenRef,omitempty"`
+}
+
+// tokenResponse is the output expected from the command.
+type tokenResponse struct {
+	Token  string    `json:"token"`
+	Expiry time.Time `json:"expiry"`
+}
+
+func (x *Config) IsValid() bool {
+	return x.Command != ""
+}
+
+// Token runs the command and returns the token it printed.
+func (x *Config) Token() (*oauth2.Token, error) {
+	command, err := homedir.Expand(x.Command)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command(command, x.Args...)
+	cmd.Env = os.Environ()
+	for k, v := range x.Env {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", k, v))
+	}
+	// The command may prompt the user, e.g. for an SSO login.
+	cmd.Stdin = os.Stdin
+	cmd.Stderr = os.Stderr
+	stdout := new(bytes.Buffer)
+	cmd.Stdout = stdout
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("exec auth command %s failed: %v", x.Command, err)
+	}
+
+	var resp tokenResponse
+	if err := json.Unmarshal(bytes.TrimSpace(stdout.Bytes()), &resp); err != nil {
+		return nil, fmt.Errorf("exec auth command %s printed malformed output: %v", x.Command, err)
+	}
+	if strings.TrimSpace(resp.Token) == "" {
+		return nil, errors.New("exec auth command printed no token")
+	}
+
+	return &oauth2.Token{
+		AccessToken: resp.Token,
+		TokenType:   "Bearer",
+		Expiry:      resp.Expiry,
+	}, nil
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package exec
+
+import (
+	"testing"
+	"time"
+)
+
+func TestToken(t *testing.T) {
+	cfg := &Config{
+		Command: "sh",
+		Args:    []string{"-c", `echo "{\"token\": \"$TOKEN\", \"expiry\": \"2030-01-01T00:00:00Z\"}"`},
+		Env:     map[string]string{"TOKEN": "secret"},
+	}
+	if !cfg.IsValid() {
+		t.Fatalf("Expected valid exec config")
+	}
+
+	token, err := cfg.Token()
+	if err != nil {
+		t.Fatalf("Token failed: %v", err)
+	}
+	if token.AccessToken != "secret" || !token.Expiry.Equal(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("Unexpected token: %+v", token)
+	}
+}
+
+func TestToken_noexpiry(t *testing.T) {
+	cfg := &Config{Command: "echo", Args: []string{`{"token": "secret"}`}}
+
+	token, err := cfg.Token()
+	if err != nil {
+		t.Fatalf("Token failed: %v", err)
+	}
+	if token.AccessToken != "secret" || !token.Expiry.IsZero() {
+		t.Fatalf("Unexpected token: %+v", token)
+	}
+}
+
+func TestToken_errors(t *testing.T) {
+	tests := map[string]*Config{
+		"failed":    {Command: "false"},
+		"malformed": {Command: "echo", Args: []string{"token"}},
+		"empty":     {Command: "echo", Args: []string{`{"expiry": "2030-01-01T00:00:00Z"}`}},
+	}
+	for name, cfg := range tests {
+		if _, err := cfg.Token(); err == nil {
+			t.Errorf("Expected an error for %s command", name)
+		}
+	}
+}
@@ -22,6 +22,9 @@ type Config struct {
 	File string `yaml:"file"`
 
 	CachedToken *oauth2.Token `yaml:"cachedToken,omitempty"`
+
+	// CachedTokenRef references the cached token in the credential store.
+	CachedTokenRef string `yaml:"cachedTokenRef,omitempty"`
 }
 
 func (g *Config) IsEnabled() bool {
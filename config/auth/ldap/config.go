@@ -3,6 +3,9 @@ package ldap
 type Config struct {
 	Username string `yaml:"username"`
 	Password string `yaml:"password"`
+
+	// PasswordRef references the password in the credential store.
+	PasswordRef string `yaml:"passwordRef,omitempty"`
 }
 
 func (l *Config) IsValid() bool {
@@ -19,16 +19,25 @@ import (
 )
 
 // Config is the configuration for using OAuth2.0 to
-// authenticate with Spinnaker
+// authenticate with Spinnaker.
+// If DeviceAuthUrl is set, the device authorization grant (RFC 8628) is used
+// instead of a browser redirect, and AuthUrl is not required.
+// Otherwise the browser is redirected back to CallbackPort on localhost, or a
+// random free port if it is not set.
 type Config struct {
-	TokenUrl     string        `yaml:"tokenUrl"`
-	AuthUrl      string        `yaml:"authUrl"`
-	ClientId     string        `yaml:"clientId"`
-	ClientSecret string        `yaml:"clientSecret"`
-	Scopes       []string      `yaml:"scopes"`
-	CachedToken  *oauth2.Token `yaml:"cachedToken,omitempty"`
+	TokenUrl      string        `yaml:"tokenUrl"`
+	AuthUrl       string        `yaml:"authUrl"`
+	DeviceAuthUrl string        `yaml:"deviceAuthUrl,omitempty"`
+	CallbackPort  int           `yaml:"callbackPort,omitempty"`
+	ClientId      string        `yaml:"clientId"`
+	ClientSecret  string        `yaml:"clientSecret"`
+	Scopes        []string      `yaml:"scopes"`
+	CachedToken   *oauth2.Token `yaml:"cachedToken,omitempty"`
+
+	// CachedTokenRef references the cached token in the credential store.
+	CachedTokenRef string `yaml:"cachedTokenRef,omitempty"`
 }
 
 func (x *Config) IsValid() bool {
-	return x.TokenUrl != "" && x.AuthUrl != "" && len(x.Scopes) != 0
+	return x.TokenUrl != "" && (x.AuthUrl != "" || x.DeviceAuthUrl != "") && len(x.Scopes) != 0
 }
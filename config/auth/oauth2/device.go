@@ -0,0 +1,191 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package oauth2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+// defaultPollInterval is the interval between token requests when the
+// authorization server does not specify one, see RFC 8628 section 3.2.
+var defaultPollInterval = 5 * time.Second
+
+// DeviceAuthorization is the response to a device authorization request,
+// see RFC 8628 section 3.2.
+type DeviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationUri         string `json:"verification_uri"`
+	VerificationUriComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+
+	// Google names the verification URI verification_url.
+	VerificationUrl string `json:"verification_url"`
+}
+
+// VerificationTarget returns the URI the user should visit to enter the user code.
+func (d *DeviceAuthorization) VerificationTarget() string {
+	if d.VerificationUri != "" {
+		return d.VerificationUri
+	}
+	return d.VerificationUrl
+}
+
+// deviceTokenResponse is a token response, or an error response while the
+// user has not yet completed the authorization, see RFC 8628 section 3.5.
+type deviceTokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`
+	RefreshToken     string `json:"refresh_token"`
+	ExpiresIn        int    `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// IsDeviceFlow reports whether the device authorization grant is configured.
+func (x *Config) IsDeviceFlow() bool {
+	return x.DeviceAuthUrl != ""
+}
+
+// RequestDeviceAuthorization starts the device authorization grant by
+// requesting a device code and user code from the authorization server.
+func (x *Config) RequestDeviceAuthorization(ctx context.Context, client *http.Client) (*DeviceAuthorization, error) {
+	form := url.Values{}
+	form.Set("client_id", x.ClientId)
+	if len(x.Scopes) > 0 {
+		form.Set("scope", strings.Join(x.Scopes, " "))
+	}
+
+	body, status, err := x.postForm(ctx, client, x.DeviceAuthUrl, form)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("device authorization request failed, status code: %d, body: %s", status, body)
+	}
+
+	auth := &DeviceAuthorization{}
+	if err := json.Unmarshal(body, auth); err != nil {
+		return nil, fmt.Errorf("could not parse device authorization response: %v", err)
+	}
+	if auth.DeviceCode == "" || auth.UserCode == "" || auth.VerificationTarget() == "" {
+		return nil, errors.New("device authorization response is missing device_code, user_code or verification_uri")
+	}
+	return auth, nil
+}
+
+// PollDeviceToken polls the token endpoint until the user completes the
+// device authorization, the device code expires or ctx is done, in which case
+// the returned error wraps ctx.Err().
+func (x *Config) PollDeviceToken(ctx context.Context, client *http.Client, auth *DeviceAuthorization) (*oauth2.Token, error) {
+	parent := ctx
+	interval := time.Duration(auth.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	if auth.ExpiresIn > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(auth.ExpiresIn)*time.Second)
+		defer cancel()
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", deviceCodeGrantType)
+	form.Set("device_code", auth.DeviceCode)
+	form.Set("client_id", x.ClientId)
+	if x.ClientSecret != "" {
+		form.Set("client_secret", x.ClientSecret)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			if parent.Err() != nil {
+				return nil, fmt.Errorf("stopped waiting for device authorization: %w", parent.Err())
+			}
+			return nil, fmt.Errorf("device code expired before authorization was completed: %w", ctx.Err())
+		case <-time.After(interval):
+		}
+
+		body, _, err := x.postForm(ctx, client, x.TokenUrl, form)
+		if err != nil {
+			return nil, err
+		}
+		resp := &deviceTokenResponse{}
+		if err := json.Unmarshal(body, resp); err != nil {
+			return nil, fmt.Errorf("could not parse token response: %v", err)
+		}
+
+		switch resp.Error {
+		case "":
+			if resp.AccessToken == "" {
+				return nil, errors.New("token response is missing access_token")
+			}
+			token := &oauth2.Token{
+				AccessToken:  resp.AccessToken,
+				TokenType:    resp.TokenType,
+				RefreshToken: resp.RefreshToken,
+			}
+			if resp.ExpiresIn > 0 {
+				token.Expiry = time.Now().Add(time.Duration(resp.ExpiresIn) * time.Second)
+			}
+			return token, nil
+		case "authorization_pending":
+		case "slow_down":
+			interval += 5 * time.Second
+		default:
+			if resp.ErrorDescription != "" {
+				return nil, fmt.Errorf("device authorization failed: %s: %s", resp.Error, resp.ErrorDescription)
+			}
+			return nil, fmt.Errorf("device authorization failed: %s", resp.Error)
+		}
+	}
+}
+
+// postForm posts the form and returns the response body. JSON is requested
+// explicitly as some authorization servers answer form encoded otherwise.
+func (x *Config) postForm(ctx context.Context, client *http.Client, endpoint string, form url.Values) ([]byte, int, error) {
+	req, err := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, 0, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+	return body, resp.StatusCode, nil
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package oauth2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDeviceFlow(t *testing.T) {
+	defer stubPollInterval()()
+	ts := testDeviceServer("")
+	defer ts.Close()
+
+	cfg := &Config{
+		DeviceAuthUrl: ts.URL + "/device",
+		TokenUrl:      ts.URL + "/token",
+		ClientId:      "spin",
+		Scopes:        []string{"email"},
+	}
+	if !cfg.IsValid() || !cfg.IsDeviceFlow() {
+		t.Fatalf("Expected valid device flow config")
+	}
+
+	auth, err := cfg.RequestDeviceAuthorization(context.Background(), ts.Client())
+	if err != nil {
+		t.Fatalf("Device authorization failed: %s", err)
+	}
+	if auth.UserCode != "ABCD-EFGH" || auth.VerificationTarget() != "https://example.com/device" {
+		t.Fatalf("Unexpected device authorization: %+v", auth)
+	}
+
+	token, err := cfg.PollDeviceToken(context.Background(), ts.Client(), auth)
+	if err != nil {
+		t.Fatalf("Polling for token failed: %s", err)
+	}
+	if token.AccessToken != "access" || token.RefreshToken != "refresh" || token.Expiry.IsZero() {
+		t.Fatalf("Unexpected token: %+v", token)
+	}
+}
+
+func TestDeviceFlow_denied(t *testing.T) {
+	defer stubPollInterval()()
+	ts := testDeviceServer("access_denied")
+	defer ts.Close()
+
+	cfg := &Config{
+		DeviceAuthUrl: ts.URL + "/device",
+		TokenUrl:      ts.URL + "/token",
+		ClientId:      "spin",
+		Scopes:        []string{"email"},
+	}
+	auth, err := cfg.RequestDeviceAuthorization(context.Background(), ts.Client())
+	if err != nil {
+		t.Fatalf("Device authorization failed: %s", err)
+	}
+	if _, err := cfg.PollDeviceToken(context.Background(), ts.Client(), auth); err == nil {
+		t.Fatalf("Expected error when authorization is denied")
+	}
+}
+
+func TestDeviceFlow_canceled(t *testing.T) {
+	defer stubPollInterval()()
+	ts := testDeviceServer("")
+	defer ts.Close()
+
+	cfg := &Config{
+		DeviceAuthUrl: ts.URL + "/device",
+		TokenUrl:      ts.URL + "/token",
+		ClientId:      "spin",
+		Scopes:        []string{"email"},
+	}
+	auth, err := cfg.RequestDeviceAuthorization(context.Background(), ts.Client())
+	if err != nil {
+		t.Fatalf("Device authorization failed: %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = cfg.PollDeviceToken(ctx, ts.Client(), auth)
+	if !errors.Is(err, context.Canceled) || strings.Contains(err.Error(), "expired") {
+		t.Fatalf("Expected a cancellation error, got: %v", err)
+	}
+}
+
+func stubPollInterval() func() {
+	defaultPollInterval = time.Millisecond
+	return func() { defaultPollInterval = 5 * time.Second }
+}
+
+// testDeviceServer spins up a local authorization server. The token endpoint
+// answers authorization_pending once, then with a token or, if finalError is
+// set, with that error.
+func testDeviceServer(finalError string) *httptest.Server {
+	polls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/device", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("client_id") != "spin" || r.FormValue("scope") != "email" {
+			http.Error(w, `{"error": "invalid_request"}`, http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintln(w, `{"device_code": "device", "user_code": "ABCD-EFGH", "verification_uri": "https://example.com/device", "expires_in": 600}`)
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("grant_type") != deviceCodeGrantType || r.FormValue("device_code") != "device" {
+			http.Error(w, `{"error": "invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		polls++
+		switch {
+		case polls == 1:
+			http.Error(w, `{"error": "authorization_pending"}`, http.StatusBadRequest)
+		case finalError != "":
+			http.Error(w, fmt.Sprintf(`{"error": %q}`, finalError), http.StatusBadRequest)
+		default:
+			fmt.Fprintln(w, `{"access_token": "access", "token_type": "Bearer", "refresh_token": "refresh", "expires_in": 3600}`)
+		}
+	})
+	return httptest.NewServer(mux)
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package oauth2
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// stateTokenLen is the number of random bytes in the state parameter.
+const stateTokenLen = 32
+
+// LoopbackReceiver receives the authorization code redirect of the OAuth2
+// authorization code flow on a local port, see RFC 8252 section 7.3.
+type LoopbackReceiver struct {
+	listener net.Listener
+	server   *http.Server
+	state    string
+	result   chan loopbackResult
+}
+
+type loopbackResult struct {
+	code string
+	err  error
+}
+
+// NewLoopbackReceiver starts listening for the redirect on the given port of
+// localhost, or on a random free port if port is 0.
+func NewLoopbackReceiver(port int) (*LoopbackReceiver, error) {
+	stateToken := make([]byte, stateTokenLen)
+	if _, err := rand.Read(stateToken); err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("localhost:%d", port))
+	if err != nil {
+		return nil, fmt.Errorf("could not listen for the OAuth2 redirect: %v", err)
+	}
+
+	r := &LoopbackReceiver{
+		listener: listener,
+		state:    base64.RawURLEncoding.EncodeToString(stateToken),
+		result:   make(chan loopbackResult, 1),
+	}
+	r.server = &http.Server{Handler: r}
+	go r.server.Serve(listener)
+	return r, nil
+}
+
+// RedirectURL is the redirect URL to send in the authorization request.
+func (r *LoopbackReceiver) RedirectURL() string {
+	return fmt.Sprintf("http://localhost:%d", r.listener.Addr().(*net.TCPAddr).Port)
+}
+
+// State is the state parameter to send in the authorization request. The
+// redirect is only accepted if it carries the same state.
+func (r *LoopbackReceiver) State() string {
+	return r.state
+}
+
+// Wait waits for the redirect and returns the authorization code. If ctx is
+// done first, the returned error wraps ctx.Err().
+func (r *LoopbackReceiver) Wait(ctx context.Context) (string, error) {
+	select {
+	case result := <-r.result:
+		return result.code, result.err
+	case <-ctx.Done():
+		if ctx.Err() == context.DeadlineExceeded {
+			return "", fmt.Errorf("timed out waiting for the OAuth2 redirect: %w", ctx.Err())
+		}
+		return "", fmt.Errorf("stopped waiting for the OAuth2 redirect: %w", ctx.Err())
+	}
+}
+
+// Close stops listening for the redirect.
+func (r *LoopbackReceiver) Close() error {
+	return r.server.Close()
+}
+
+func (r *LoopbackReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path != "/" {
+		http.NotFound(w, req)
+		return
+	}
+
+	var result loopbackResult
+	switch {
+	case req.FormValue("state") != r.state:
+		result.err = errors.New("OAuth2 redirect has an invalid state parameter")
+	case req.FormValue("error") != "":
+		result.err = fmt.Errorf("OAuth2 authorization failed: %s %s", req.FormValue("error"), req.FormValue("error_description"))
+	case req.FormValue("code") == "":
+		result.err = errors.New("OAuth2 redirect has no authorization code")
+	default:
+		result.code = req.FormValue("code")
+	}
+
+	if result.err != nil {
+		http.Error(w, result.err.Error(), http.StatusBadRequest)
+	} else {
+		fmt.Fprintln(w, "Authentication complete. You may close this window and return to spin.")
+	}
+
+	// Only the first redirect is used.
+	select {
+	case r.result <- result:
+	default:
+	}
+}
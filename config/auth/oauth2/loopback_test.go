@@ -0,0 +1,127 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package oauth2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoopbackReceiver(t *testing.T) {
+	r, err := NewLoopbackReceiver(0)
+	if err != nil {
+		t.Fatalf("Failed to start receiver: %s", err)
+	}
+	defer r.Close()
+
+	if !strings.HasPrefix(r.RedirectURL(), "http://localhost:") || r.RedirectURL() == "http://localhost:0" {
+		t.Fatalf("Unexpected redirect URL: %s", r.RedirectURL())
+	}
+
+	resp, err := http.Get(redirectTo(r, url.Values{"state": {r.State()}, "code": {"abc"}}))
+	if err != nil {
+		t.Fatalf("Redirect failed: %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Unexpected status: %d", resp.StatusCode)
+	}
+
+	code, err := r.Wait(context.Background())
+	if err != nil {
+		t.Fatalf("Wait failed: %s", err)
+	}
+	if code != "abc" {
+		t.Fatalf("Unexpected code: %s", code)
+	}
+}
+
+func TestLoopbackReceiver_badState(t *testing.T) {
+	r, err := NewLoopbackReceiver(0)
+	if err != nil {
+		t.Fatalf("Failed to start receiver: %s", err)
+	}
+	defer r.Close()
+
+	resp, err := http.Get(redirectTo(r, url.Values{"state": {"state-token"}, "code": {"abc"}}))
+	if err != nil {
+		t.Fatalf("Redirect failed: %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("Unexpected status: %d", resp.StatusCode)
+	}
+
+	if _, err := r.Wait(context.Background()); err == nil {
+		t.Fatalf("Expected an error for a mismatched state")
+	}
+}
+
+func TestLoopbackReceiver_error(t *testing.T) {
+	r, err := NewLoopbackReceiver(0)
+	if err != nil {
+		t.Fatalf("Failed to start receiver: %s", err)
+	}
+	defer r.Close()
+
+	resp, err := http.Get(redirectTo(r, url.Values{"state": {r.State()}, "error": {"access_denied"}}))
+	if err != nil {
+		t.Fatalf("Redirect failed: %s", err)
+	}
+	resp.Body.Close()
+
+	_, err = r.Wait(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "access_denied") {
+		t.Fatalf("Expected access_denied error, got: %v", err)
+	}
+}
+
+func TestLoopbackReceiver_timeout(t *testing.T) {
+	r, err := NewLoopbackReceiver(0)
+	if err != nil {
+		t.Fatalf("Failed to start receiver: %s", err)
+	}
+	defer r.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := r.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected a timeout error, got: %v", err)
+	}
+}
+
+func TestLoopbackReceiver_canceled(t *testing.T) {
+	r, err := NewLoopbackReceiver(0)
+	if err != nil {
+		t.Fatalf("Failed to start receiver: %s", err)
+	}
+	defer r.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = r.Wait(ctx)
+	if !errors.Is(err, context.Canceled) || strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("Expected a cancellation error, got: %v", err)
+	}
+}
+
+func redirectTo(r *LoopbackReceiver, params url.Values) string {
+	return r.RedirectURL() + "/?" + params.Encode()
+}
@@ -15,13 +15,96 @@
 package config
 
 import (
+	"fmt"
+	"sort"
+
 	"github.com/spinnaker/spin/config/auth"
 )
 
 // Config is the CLI configuration kept in '~/.spin/config'.
+//
+// The top-level gate and auth settings form an unnamed default context.
+// Additional named contexts may be defined under 'contexts' and selected
+// with 'currentContext' or the --context flag.
+//
+// If CredentialHelper is set, cached tokens and passwords are kept in a
+// credential store and the config file only holds references to them.
 type Config struct {
+	Context `yaml:",inline"`
+
+	CurrentContext   string              `yaml:"currentContext,omitempty"`
+	Contexts         map[string]*Context `yaml:"contexts,omitempty"`
+	CredentialHelper string              `yaml:"credentialHelper,omitempty"`
+}
+
+// Context is a named set of settings for talking to a single Gate.
+type Context struct {
 	Gate struct {
 		Endpoint string `yaml:"endpoint"`
 	} `yaml:"gate"`
 	Auth *auth.Config `yaml:"auth"`
+
+	DefaultHeaders     map[string]string `yaml:"defaultHeaders,omitempty"`
+	DefaultApplication string            `yaml:"defaultApplication,omitempty"`
+
+	Retry *Retry `yaml:"retry,omitempty"`
+}
+
+// Retry configures retries of requests failing with transient errors, such
+// as connection resets or 502/503/504 responses. Backoffs are durations like
+// "500ms". POST requests are only retried if RetryPost is set.
+type Retry struct {
+	MaxAttempts    int    `yaml:"maxAttempts,omitempty"`
+	InitialBackoff string `yaml:"initialBackoff,omitempty"`
+	MaxBackoff     string `yaml:"maxBackoff,omitempty"`
+	RetryPost      bool   `yaml:"retryPost,omitempty"`
+}
+
+// ResolveContext returns the context with the given name. If name is empty,
+// the configured current context is used, falling back to the top-level
+// settings when no current context is set.
+//
+// The returned context is shared with the receiver, so changes made to it
+// (such as cached tokens) are persisted when the config is written back.
+func (c *Config) ResolveContext(name string) (*Context, error) {
+	if name == "" {
+		name = c.CurrentContext
+	}
+	if name == "" {
+		return &c.Context, nil
+	}
+	ctx, ok := c.Contexts[name]
+	if !ok || ctx == nil {
+		return nil, fmt.Errorf("context %q not found in config", name)
+	}
+	return ctx, nil
+}
+
+// ResolveRetry returns the retry settings of the context, falling back to the
+// top-level settings when the context sets none.
+func (c *Config) ResolveRetry(ctx *Context) *Retry {
+	if ctx != nil && ctx.Retry != nil {
+		return ctx.Retry
+	}
+	return c.Context.Retry
+}
+
+// UseContext sets the current context, failing if no context exists with
+// the given name.
+func (c *Config) UseContext(name string) error {
+	if _, ok := c.Contexts[name]; !ok {
+		return fmt.Errorf("context %q not found in config", name)
+	}
+	c.CurrentContext = name
+	return nil
+}
+
+// ContextNames returns the names of all configured contexts in sorted order.
+func (c *Config) ContextNames() []string {
+	names := make([]string, 0, len(c.Contexts))
+	for name := range c.Contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
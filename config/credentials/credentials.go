@@ -0,0 +1,43 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+// Package credentials stores secrets such as cached tokens and passwords
+// outside of the spin config file, which then only holds references to them.
+package credentials
+
+import (
+	"errors"
+)
+
+// FileStoreName is the credentialHelper value selecting the obfuscated file
+// store instead of an external helper, see FileStore.
+const FileStoreName = "file"
+
+// ErrNotFound is returned by Store.Get and Store.Erase for unknown server URLs.
+var ErrNotFound = errors.New("credentials not found")
+
+// Credentials is a secret stored under a server URL, following the docker
+// credential helper protocol.
+type Credentials struct {
+	ServerURL string
+	Username  string
+	Secret    string
+}
+
+// Store gets, stores and erases credentials keyed by server URL.
+type Store interface {
+	Get(serverURL string) (*Credentials, error)
+	Store(creds *Credentials) error
+	Erase(serverURL string) error
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package credentials
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// fileStoreName and fileStoreKeyName are the names of the obfuscated
+	// credentials and of the key obfuscating them, in the spin config directory.
+	fileStoreName    = "credentials"
+	fileStoreKeyName = "credentials.key"
+
+	// fileStoreMode only allows the owner to read the files.
+	fileStoreMode os.FileMode = 0600 // u=rw,g=,o=
+
+	keyLen = 32
+)
+
+// FileStore keeps credentials obfuscated in a file. They are encrypted with
+// AES-256-GCM, but with a key generated on first use and kept in the same
+// directory, so they are only protected by the files' 0600 mode: anyone who
+// can read the credentials file can read the key too. It keeps secrets out of
+// the config file, e.g. when sharing it, but is no substitute for an OS
+// keychain helper.
+type FileStore struct {
+	Path    string
+	KeyPath string
+}
+
+// NewFileStore returns a store keeping its files in dir.
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{
+		Path:    filepath.Join(dir, fileStoreName),
+		KeyPath: filepath.Join(dir, fileStoreKeyName),
+	}
+}
+
+func (f *FileStore) Get(serverURL string) (*Credentials, error) {
+	all, err := f.load()
+	if err != nil {
+		return nil, err
+	}
+	creds, ok := all[serverURL]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return creds, nil
+}
+
+func (f *FileStore) Store(creds *Credentials) error {
+	all, err := f.load()
+	if err != nil {
+		return err
+	}
+	all[creds.ServerURL] = creds
+	return f.save(all)
+}
+
+func (f *FileStore) Erase(serverURL string) error {
+	all, err := f.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := all[serverURL]; !ok {
+		return ErrNotFound
+	}
+	delete(all, serverURL)
+	return f.save(all)
+}
+
+func (f *FileStore) load() (map[string]*Credentials, error) {
+	all := map[string]*Credentials{}
+	ciphertext, err := ioutil.ReadFile(f.Path)
+	if os.IsNotExist(err) {
+		return all, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	gcm, err := f.cipher()
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, errors.New("credentials file is truncated")
+	}
+	nonce, ciphertext := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, errors.New("could not decrypt credentials file, the key may have changed")
+	}
+	if err := json.Unmarshal(plaintext, &all); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+func (f *FileStore) save(all map[string]*Credentials) error {
+	plaintext, err := json.Marshal(all)
+	if err != nil {
+		return err
+	}
+
+	gcm, err := f.cipher()
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(f.Path, gcm.Seal(nonce, nonce, plaintext, nil), fileStoreMode)
+}
+
+// cipher reads the key file, creating it with a random key if missing.
+func (f *FileStore) cipher() (cipher.AEAD, error) {
+	key, err := ioutil.ReadFile(f.KeyPath)
+	if os.IsNotExist(err) {
+		key = make([]byte, keyLen)
+		if _, err := io.ReadFull(rand.Reader, key); err != nil {
+			return nil, err
+		}
+		if err := ioutil.WriteFile(f.KeyPath, key, fileStoreMode); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+	if len(key) != keyLen {
+		return nil, errors.New("credentials key file is corrupt")
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
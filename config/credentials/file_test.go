@@ -0,0 +1,95 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package credentials
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestFileStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spin-credentials")
+	if err != nil {
+		t.Fatalf("Could not create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	store := NewFileStore(dir)
+	if _, err := store.Get("spin://config/basic"); err != ErrNotFound {
+		t.Fatalf("Expected ErrNotFound before storing, got: %v", err)
+	}
+
+	creds := &Credentials{ServerURL: "spin://config/basic", Username: "user", Secret: "hunter2"}
+	if err := store.Store(creds); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+
+	stored, err := ioutil.ReadFile(store.Path)
+	if err != nil {
+		t.Fatalf("Could not read credentials file: %v", err)
+	}
+	if strings.Contains(string(stored), "hunter2") {
+		t.Fatalf("Credentials file is not obfuscated")
+	}
+	for _, path := range []string{store.Path, store.KeyPath} {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("Could not stat %s: %v", path, err)
+		}
+		if info.Mode().Perm() != 0600 {
+			t.Fatalf("Unexpected mode %v for %s", info.Mode(), path)
+		}
+	}
+
+	got, err := NewFileStore(dir).Get("spin://config/basic")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if *got != *creds {
+		t.Fatalf("Unexpected credentials: %+v", got)
+	}
+
+	if err := store.Erase("spin://config/basic"); err != nil {
+		t.Fatalf("Erase failed: %v", err)
+	}
+	if _, err := store.Get("spin://config/basic"); err != ErrNotFound {
+		t.Fatalf("Expected ErrNotFound after erasing, got: %v", err)
+	}
+	if err := store.Erase("spin://config/basic"); err != ErrNotFound {
+		t.Fatalf("Expected ErrNotFound erasing twice, got: %v", err)
+	}
+}
+
+func TestFileStore_wrongKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spin-credentials")
+	if err != nil {
+		t.Fatalf("Could not create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	store := NewFileStore(dir)
+	if err := store.Store(&Credentials{ServerURL: "spin://config/basic", Secret: "hunter2"}); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	if err := os.Remove(store.KeyPath); err != nil {
+		t.Fatalf("Could not remove key file: %v", err)
+	}
+
+	if _, err := store.Get("spin://config/basic"); err == nil {
+		t.Fatalf("Expected decryption to fail with a new key")
+	}
+}
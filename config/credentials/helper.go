@@ -0,0 +1,102 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package credentials
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+// helperPrefix is prepended to the credentialHelper name to find the helper
+// executable on the PATH.
+const helperPrefix = "spin-credential-"
+
+// notFoundMessage is printed by docker credential helpers for unknown server
+// URLs.
+const notFoundMessage = "credentials not found in native keychain"
+
+// HelperStore delegates to an external spin-credential-<name> executable
+// speaking the docker credential helper protocol.
+type HelperStore struct {
+	Program string
+}
+
+// NewHelperStore returns a store using the helper for name.
+func NewHelperStore(name string) *HelperStore {
+	return &HelperStore{Program: HelperProgram(name)}
+}
+
+// HelperProgram is the executable name of the helper for name.
+func HelperProgram(name string) string {
+	return helperPrefix + name
+}
+
+// HelperInstalled reports whether the helper for name is on the PATH.
+func HelperInstalled(name string) bool {
+	_, err := exec.LookPath(HelperProgram(name))
+	return err == nil
+}
+
+func (h *HelperStore) Get(serverURL string) (*Credentials, error) {
+	out, err := h.run("get", strings.NewReader(serverURL))
+	if err != nil {
+		return nil, err
+	}
+	creds := &Credentials{}
+	if err := json.Unmarshal(out, creds); err != nil {
+		return nil, fmt.Errorf("%s returned malformed credentials: %v", h.Program, err)
+	}
+	if creds.ServerURL == "" {
+		creds.ServerURL = serverURL
+	}
+	return creds, nil
+}
+
+func (h *HelperStore) Store(creds *Credentials) error {
+	payload, err := json.Marshal(creds)
+	if err != nil {
+		return err
+	}
+	_, err = h.run("store", bytes.NewReader(payload))
+	return err
+}
+
+func (h *HelperStore) Erase(serverURL string) error {
+	_, err := h.run("erase", strings.NewReader(serverURL))
+	return err
+}
+
+func (h *HelperStore) run(action string, input io.Reader) ([]byte, error) {
+	cmd := exec.Command(h.Program, action)
+	cmd.Stdin = input
+	stderr := new(bytes.Buffer)
+	cmd.Stderr = stderr
+	out, err := cmd.Output()
+	if err != nil {
+		message := strings.TrimSpace(string(out) + stderr.String())
+		if strings.Contains(message, notFoundMessage) {
+			return nil, ErrNotFound
+		}
+		if message == "" {
+			message = err.Error()
+		}
+		return nil, fmt.Errorf("%s %s failed: %s", h.Program, action, message)
+	}
+	return out, nil
+}
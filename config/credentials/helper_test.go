@@ -0,0 +1,83 @@
+// Copyright (c) 2020, Google, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+
+package credentials
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testHelper is a credential helper keeping a single entry in a file next to
+// it.
+const testHelper = `#!/bin/sh
+store="$(dirname "$0")/entry"
+case "$1" in
+store) cat > "$store" ;;
+get)
+  if [ -f "$store" ]; then cat "$store"; else echo "credentials not found in native keychain"; exit 1; fi ;;
+erase) rm "$store" ;;
+esac
+`
+
+func TestHelperStore(t *testing.T) {
+	defer installTestHelper(t)()
+
+	if !HelperInstalled("test") || HelperInstalled("missing") {
+		t.Fatalf("Unexpected helper lookup result")
+	}
+
+	store := NewHelperStore("test")
+	if _, err := store.Get("spin://config/oauth2"); err != ErrNotFound {
+		t.Fatalf("Expected ErrNotFound before storing, got: %v", err)
+	}
+
+	creds := &Credentials{ServerURL: "spin://config/oauth2", Username: "token", Secret: `{"access_token":"access"}`}
+	if err := store.Store(creds); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	got, err := store.Get("spin://config/oauth2")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if *got != *creds {
+		t.Fatalf("Unexpected credentials: %+v", got)
+	}
+
+	if err := store.Erase("spin://config/oauth2"); err != nil {
+		t.Fatalf("Erase failed: %v", err)
+	}
+	if _, err := store.Get("spin://config/oauth2"); err != ErrNotFound {
+		t.Fatalf("Expected ErrNotFound after erasing, got: %v", err)
+	}
+}
+
+// installTestHelper puts testHelper on the PATH as spin-credential-test.
+func installTestHelper(t *testing.T) func() {
+	dir, err := ioutil.TempDir("", "spin-credential-helper")
+	if err != nil {
+		t.Fatalf("Could not create temp dir: %v", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, HelperProgram("test")), []byte(testHelper), 0700); err != nil {
+		t.Fatalf("Could not write helper: %v", err)
+	}
+	path := os.Getenv("PATH")
+	os.Setenv("PATH", dir+string(os.PathListSeparator)+path)
+	return func() {
+		os.Setenv("PATH", path)
+		os.RemoveAll(dir)
+	}
+}
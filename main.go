@@ -23,15 +23,24 @@ import (
 )
 
 func main() {
+	os.Exit(run())
+}
+
+// run executes the command and returns the exit code. It is separate from
+// main so that deferred calls run before spin exits.
+func run() int {
 	command, options := cmd.NewCmdRoot(os.Stdout, os.Stderr)
+	defer options.Cancel()
 	assembler.AddSubCommands(command, options)
 
 	if err := command.Execute(); err != nil {
+		code, err := options.ExitStatus(err)
 		if options.Ui != nil {
 			options.Ui.Error(err.Error())
 		} else {
 			fmt.Fprintf(os.Stderr, "\n%v\n", err)
 		}
-		os.Exit(1)
+		return code
 	}
+	return 0
 }